@@ -0,0 +1,77 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"configcraft/internal/config"
+)
+
+// runBatch 使用同一个schema校验并生成目录或glob匹配的所有配置，输出汇总报告
+// 有配置失败时返回1，便于CI判断
+func runBatch(args []string) int {
+	flags := flag.NewFlagSet("batch", flag.ExitOnError)
+	schemaPath := flags.String("schema", "", "schema文件（必填）")
+	outputDir := flags.String("out", "", "输出目录，默认输出到各配置文件旁")
+	format := flags.String("format", config.ReportText, "报告格式: "+strings.Join(config.ReportFormats, ", "))
+	reportPath := flags.String("report", "", "报告文件，默认输出到标准输出")
+	validateOnly := flags.Bool("validate-only", false, "只校验，不生成输出")
+	outputFormat := flags.String("output-format", config.FormatConf, "输出格式，conf以外的格式由schema的templates定义")
+	template := flags.String("template", "", "生成模板文件，指定时忽略-output-format")
+	mode := flags.String("mode", config.OutputFull, "输出模式: "+strings.Join(config.OutputModes, ", "))
+	backups := flags.Int("backups", 0, "覆盖输出文件前保留的历史版本数")
+	force := flags.Bool("force", false, "覆盖在上次生成后被手工修改的conf文件")
+	flags.Parse(args)
+
+	if *schemaPath == "" || flags.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: cli batch -schema schema.yaml [options] <dir|glob>...")
+		flags.PrintDefaults()
+		return 2
+	}
+
+	configPaths, err := config.ExpandConfigPaths(flags.Args(), *schemaPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	options := config.BatchOptions{
+		OutputDir:    *outputDir,
+		ValidateOnly: *validateOnly,
+		Format:       *outputFormat,
+		Template:     *template,
+		Mode:         *mode,
+		Backups:      *backups,
+		Force:        *force,
+	}
+	results, err := config.RunBatch(*schemaPath, configPaths, options, func(done int, result config.BatchResult) {
+		fmt.Fprintf(os.Stderr, "[%d/%d] %s %s\n", done, len(configPaths), strings.ToUpper(result.Status()), result.ConfigPath)
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	var out io.Writer = os.Stdout
+	if *reportPath != "" {
+		file, err := os.Create(*reportPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating report: %v\n", err)
+			return 1
+		}
+		defer file.Close()
+		out = file
+	}
+	if err := config.WriteBatchReport(out, *format, *schemaPath, results); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing report: %v\n", err)
+		return 1
+	}
+
+	if config.SummarizeBatch(results).Failed > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"configcraft/internal/config"
+)
+
+// runBuild 重新生成项目中所有配置的输出文件，任一配置失败时返回非零退出码
+func runBuild(args []string) int {
+	flags := flag.NewFlagSet("build", flag.ExitOnError)
+	only := flags.String("config", "", "只生成指定名称的配置")
+	force := flags.Bool("force", false, "覆盖在上次生成后被手工修改的conf文件")
+	flags.Parse(args)
+
+	projectPath := config.ProjectFileName
+	if flags.NArg() > 0 {
+		projectPath = flags.Arg(0)
+	}
+
+	project, err := config.LoadProject(projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading project: %v\n", err)
+		return 1
+	}
+
+	name := project.Name
+	if name == "" {
+		name = project.FilePath
+	}
+	fmt.Printf("Building project: %s (%d configs)\n", name, len(project.Configs))
+
+	failed, built := 0, 0
+	for _, cfg := range project.Configs {
+		if *only != "" && cfg.Name != *only {
+			continue
+		}
+		built++
+
+		outputs, err := config.BuildProjectConfig(cfg, *force)
+		var modified *config.ConfModifiedError
+		if errors.As(err, &modified) {
+			failed++
+			fmt.Printf("  ✗ %s: %v\n", cfg.Name, err)
+			printConfEdits(modified.Edits)
+			continue
+		}
+		if err != nil {
+			failed++
+			fmt.Printf("  ✗ %s: %v\n", cfg.Name, err)
+			continue
+		}
+		fmt.Printf("  ✓ %s\n", cfg.Name)
+		for _, output := range outputs {
+			fmt.Printf("      -> %s\n", output)
+		}
+	}
+
+	if built == 0 {
+		fmt.Fprintf(os.Stderr, "No config named %q in project\n", *only)
+		return 1
+	}
+	fmt.Printf("\n%d built, %d failed\n", built-failed, failed)
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// printConfEdits 列出conf文件中的手工修改，并提示如何处理
+func printConfEdits(edits []config.ConfEdit) {
+	for _, edit := range edits {
+		switch {
+		case edit.Removed:
+			fmt.Printf("      %s removed (was %s)\n", edit.Key, edit.Generated)
+		case edit.Generated == "":
+			fmt.Printf("      %s added = %s\n", edit.Key, edit.Current)
+		default:
+			fmt.Printf("      %s %s -> %s\n", edit.Key, edit.Generated, edit.Current)
+		}
+	}
+	fmt.Println("      Re-run with -force to discard the hand edits, or import them into the YAML first")
+}
@@ -6,9 +6,52 @@ import (
 	"path/filepath"
 
 	"configcraft/internal/config"
+	"configcraft/internal/version"
 )
 
+// command 一个CLI子命令
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) int // 返回进程退出码
+}
+
+var commands = []command{
+	{"info", "info [schema.yaml]                  显示schema的基本信息", runInfo},
+	{"build", "build [-config 名称] [project.yaml]  重新生成项目中所有配置的输出文件", runBuild},
+	{"batch", "batch -schema s.yaml <目录|glob>...  批量校验并生成，输出text/json/junit汇总报告", runBatch},
+	{"verify", "verify <文件|project.yaml>...        检查生成的文件是否在生成后被手工修改", runVerify},
+}
+
 func main() {
+	// 不带参数时保持以前的行为，显示默认schema的信息
+	if len(os.Args) < 2 {
+		os.Exit(runInfo(nil))
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == os.Args[1] {
+			os.Exit(cmd.run(os.Args[2:]))
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Unknown command: %s\n\n", os.Args[1])
+	printUsage()
+	os.Exit(2)
+}
+
+func printUsage() {
+	fmt.Printf("%s - CLI Version %s\n\n", version.AppName, version.Version)
+	fmt.Println("Usage: cli <command> [arguments]")
+	fmt.Println()
+	fmt.Println("Commands:")
+	for _, cmd := range commands {
+		fmt.Printf("  %s\n", cmd.usage)
+	}
+}
+
+// runInfo 显示schema的基本信息
+func runInfo(args []string) int {
 	fmt.Println("ConfigCraft - CLI Version")
 	fmt.Println("=========================")
 	
@@ -16,9 +59,12 @@ func main() {
 	
 	// Load schema
 	schemaPath := filepath.Join("..", "assets", "schemas", "dhf-real-schema.yaml")
+	if len(args) > 0 {
+		schemaPath = args[0]
+	}
 	if err := parser.LoadSchema(schemaPath); err != nil {
 		fmt.Printf("Error loading schema: %v\n", err)
-		return
+		return 1
 	}
 	
 	schema := parser.GetSchema()
@@ -30,4 +76,5 @@ func main() {
 	}
 	
 	fmt.Println("\nUse GUI version (dhf-config-manager.exe) for full configuration.")
-}
\ No newline at end of file
+	return 0
+}
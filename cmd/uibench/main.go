@@ -0,0 +1,269 @@
+// uibench 测量大schema下分组树和编辑区的响应时间
+//
+// 用生成的schema（默认5000个字段）在Fyne的测试驱动中创建与主界面相同的树和编辑区，
+// 重复执行点击分组、展开、输入、切换分组、跳转到字段等操作并统计耗时。
+// 任一操作的p95超过预算时返回1，可以在CI中检查界面性能是否退化：
+//
+//	go run ./cmd/uibench -fields 5000 -budget 50ms
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime/pprof"
+	"sort"
+	"strconv"
+	"time"
+
+	"configcraft/internal/config"
+	"configcraft/internal/models"
+	"configcraft/internal/ui/components"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/test"
+)
+
+func main() {
+	os.Exit(run())
+}
+
+// run 执行所有操作并输出耗时，返回进程退出码
+func run() int {
+	fields := flag.Int("fields", 5000, "字段总数")
+	sections := flag.Int("sections", 10, "分组数")
+	groups := flag.Int("groups", 10, "每个分组的子分组数")
+	flat := flag.Int("flat", 2000, "第一个分组中直接包含的字段数（最大的一页）")
+	runs := flag.Int("runs", 20, "每个操作的重复次数")
+	budget := flag.Duration("budget", 50*time.Millisecond, "每个操作p95的上限")
+	cpuProfile := flag.String("cpuprofile", "", "把CPU profile写入该文件，用go tool pprof查看")
+	flag.Parse()
+
+	if *flat > *fields || *sections < 1 || *groups < 1 || *runs < 1 {
+		fmt.Fprintln(os.Stderr, "Usage: uibench [-fields n] [-sections n] [-groups n] [-flat n] [-runs n] [-budget d] [-cpuprofile file]")
+		return 2
+	}
+
+	schema := generateSchema(*fields, *sections, *groups, *flat)
+	b := newBench(schema)
+	defer b.window.Close()
+
+	if *cpuProfile != "" {
+		file, err := os.Create(*cpuProfile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating profile: %v\n", err)
+			return 1
+		}
+		defer file.Close()
+		pprof.StartCPUProfile(file)
+		defer pprof.StopCPUProfile()
+	}
+
+	fmt.Printf("Schema: %d fields, %d sections, %d groups per section, %d fields on the largest page\n\n",
+		*fields, *sections, *groups, *flat)
+	fmt.Printf("%-28s %10s %10s %10s\n", "operation", "avg", "p95", "max")
+
+	failed := false
+	for _, op := range b.operations() {
+		samples := make([]time.Duration, *runs)
+		for i := range samples {
+			start := time.Now()
+			op.run(i)
+			samples[i] = time.Since(start)
+		}
+		avg, p95, max := summarize(samples)
+		mark := ""
+		if p95 > *budget {
+			mark = "  over budget"
+			failed = true
+		}
+		fmt.Printf("%-28s %10s %10s %10s%s\n", op.name, round(avg), round(p95), round(max), mark)
+	}
+
+	if failed {
+		fmt.Printf("\nSome operations exceed the %s budget\n", *budget)
+		return 1
+	}
+	return 0
+}
+
+// generateSchema 生成测试用的schema：第一个分组直接包含flat个字段，其余字段平均分到其他子分组
+// 字段类型轮流使用text、number、boolean、select和combo，部分字段带描述和提示
+func generateSchema(fields, sections, groups, flat int) *models.Schema {
+	schema := &models.Schema{
+		SchemaVersion: "1.0",
+		DisplayName:   models.Text{Default: "uibench"},
+		Sections:      make(map[string]models.ConfigSection),
+	}
+
+	for s := 0; s < sections; s++ {
+		section := models.ConfigSection{
+			Name:   models.Text{Default: fmt.Sprintf("Section %02d", s)},
+			Fields: make(map[string]models.ConfigField),
+			Groups: make(map[string]models.ConfigGroup),
+		}
+		for g := 0; g < groups; g++ {
+			section.Groups[fmt.Sprintf("group_%02d", g)] = models.ConfigGroup{
+				Name:   models.Text{Default: fmt.Sprintf("Group %02d.%02d", s, g)},
+				Fields: make(map[string]models.ConfigField),
+			}
+		}
+		schema.Sections[sectionKey(s)] = section
+	}
+
+	for i := 0; i < fields; i++ {
+		key := fmt.Sprintf("field_%05d", i)
+		if i < flat {
+			schema.Sections[sectionKey(0)].Fields[key] = generateField(i)
+			continue
+		}
+		slot := i - flat
+		s := slot % sections
+		g := (slot / sections) % groups
+		schema.Sections[sectionKey(s)].Groups[fmt.Sprintf("group_%02d", g)].Fields[key] = generateField(i)
+	}
+	return schema
+}
+
+func sectionKey(s int) string {
+	return fmt.Sprintf("section_%02d", s)
+}
+
+func generateField(i int) models.ConfigField {
+	field := models.ConfigField{Label: models.Text{Default: "Field " + strconv.Itoa(i)}}
+	options := []models.ConfigOption{
+		{Value: "alpha", Label: models.Text{Default: "Alpha"}},
+		{Value: "beta", Label: models.Text{Default: "Beta"}},
+	}
+	switch i % 5 {
+	case 0:
+		field.Type, field.Default = "text", "value"
+	case 1:
+		field.Type, field.Default = "number", i
+	case 2:
+		field.Type, field.Default = "boolean", false
+	case 3:
+		field.Type, field.Default, field.Options = "select", "alpha", options
+	case 4:
+		field.Type, field.Default, field.Options = "combo", "beta", options
+	}
+	if i%3 == 0 {
+		field.Description = models.Text{Default: "A longer description that wraps onto a second line in narrow windows " + strconv.Itoa(i)}
+	}
+	if i%4 == 0 {
+		field.Tooltip = models.Text{Default: "Help for field " + strconv.Itoa(i)}
+	}
+	return field
+}
+
+// bench 与主界面相同的树和编辑区，编辑时按文档的方式更新树中的修改数
+type bench struct {
+	schema     *models.Schema
+	userConfig *models.UserConfig
+	tree       *components.ConfigTree
+	editor     *components.ConfigEditor
+	window     fyne.Window
+	nodes      []string // 所有分组和子分组
+	fields     []string // 最大一页中的字段
+}
+
+func newBench(schema *models.Schema) *bench {
+	app := test.NewApp()
+	b := &bench{
+		schema:     schema,
+		userConfig: &models.UserConfig{Values: make(map[string]interface{})},
+		tree:       components.NewConfigTree(),
+		editor:     components.NewConfigEditor(),
+	}
+
+	b.tree.SetSelectionCallback(func(nodeID string) {
+		b.editor.ShowSection(nodeID)
+	})
+	b.editor.SetChangeCallback(func(string) {
+		b.tree.SetOverrideCounts(config.OverrideCounts(b.schema, b.userConfig))
+	})
+	b.editor.SetSchema(schema)
+	b.editor.SetConfig(b.userConfig)
+	b.tree.LoadSchema(schema)
+
+	b.window = app.NewWindow("uibench")
+	split := container.NewHSplit(b.tree.Container(), b.editor.Container())
+	split.SetOffset(0.25)
+	b.window.SetContent(split)
+	b.window.Resize(fyne.NewSize(900, 650))
+
+	for _, key := range sortedKeys(schema.Sections) {
+		b.nodes = append(b.nodes, key)
+		for _, groupKey := range sortedKeys(schema.Sections[key].Groups) {
+			b.nodes = append(b.nodes, key+"."+groupKey)
+		}
+	}
+	for _, key := range sortedKeys(schema.Sections[sectionKey(0)].Fields) {
+		b.fields = append(b.fields, sectionKey(0)+"."+key)
+	}
+	return b
+}
+
+// operation 一个被测量的界面操作，run的参数为第几次执行
+type operation struct {
+	name string
+	run  func(i int)
+}
+
+func (b *bench) operations() []operation {
+	largest := sectionKey(0)
+	return []operation{
+		{"load schema into tree", func(int) {
+			b.tree.LoadSchema(b.schema)
+		}},
+		{"select tree node", func(i int) {
+			b.tree.SelectNodeByID(b.nodes[(i*7)%len(b.nodes)])
+		}},
+		{"show largest section", func(int) {
+			b.tree.SelectNodeByID(largest)
+		}},
+		{"show group", func(i int) {
+			b.tree.SelectNodeByID(b.nodes[1+(i%(len(b.nodes)-1))])
+		}},
+		{"jump to field", func(i int) {
+			b.tree.SelectNodeByID(largest)
+			b.editor.FocusField(b.fields[len(b.fields)-1-(i*97)%len(b.fields)])
+		}},
+		{"type into field", func(i int) {
+			if i == 0 {
+				b.tree.SelectNodeByID(largest)
+				b.editor.FocusField(b.fields[0])
+			}
+			if focused := b.window.Canvas().Focused(); focused != nil {
+				test.Type(focused, "x")
+			}
+		}},
+	}
+}
+
+// summarize 平均值、p95和最大值
+func summarize(samples []time.Duration) (avg, p95, max time.Duration) {
+	sorted := append([]time.Duration(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, sample := range sorted {
+		total += sample
+	}
+	index := (len(sorted)*95+99)/100 - 1
+	return total / time.Duration(len(sorted)), sorted[index], sorted[len(sorted)-1]
+}
+
+func round(d time.Duration) time.Duration {
+	return d.Round(10 * time.Microsecond)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"configcraft/internal/config"
+)
+
+// runVerify 检查生成的文件是否在生成后被手工修改，有文件被修改或无法校验时返回非零退出码
+// 参数可以是生成的文件，也可以是项目文件（检查项目中所有配置的输出）
+func runVerify(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: cli verify <file|project.yaml>...")
+		return 2
+	}
+
+	var files []string
+	for _, arg := range args {
+		if !config.IsProjectFile(arg) {
+			files = append(files, arg)
+			continue
+		}
+		project, err := config.LoadProject(arg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading project: %v\n", err)
+			return 1
+		}
+		for _, cfg := range project.Configs {
+			for _, output := range cfg.Outputs {
+				files = append(files, output.Path)
+			}
+		}
+	}
+
+	failed := 0
+	for _, file := range files {
+		ok, err := config.VerifyFile(file)
+		switch {
+		case errors.Is(err, config.ErrNoChecksum):
+			failed++
+			fmt.Printf("  ? %s: no checksum\n", file)
+		case err != nil:
+			failed++
+			fmt.Printf("  ✗ %s: %v\n", file, err)
+		case !ok:
+			failed++
+			fmt.Printf("  ✗ %s: modified after generation\n", file)
+		default:
+			fmt.Printf("  ✓ %s\n", file)
+		}
+	}
+
+	fmt.Printf("\n%d verified, %d failed\n", len(files)-failed, failed)
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,169 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// pendingWrite 一个已写入临时文件、等待提交的目标文件
+type pendingWrite struct {
+	path     string // 目标文件
+	tmpPath  string // 已写好的临时文件
+	origPath string // 提交前为原文件留下的回滚副本（原文件不存在时为空）
+}
+
+// fileTransaction 将多个文件的写入作为整体提交
+// 先把所有内容写入同目录下的临时文件，全部成功后再依次重命名到目标位置；
+// 任何一步失败都会回滚，保证YAML与生成的conf等文件要么全部更新、要么保持原样。
+// 每个目标文件都由一次重命名直接替换，任何时刻目标路径上都有完整的旧文件或新文件
+type fileTransaction struct {
+	writes []*pendingWrite
+}
+
+func newFileTransaction() *fileTransaction {
+	return &fileTransaction{}
+}
+
+// Stage 将data写入目标文件旁的临时文件并落盘，此时目标文件尚未改变
+// 临时文件沿用目标文件现有的权限，目标文件不存在时为0644
+func (tx *fileTransaction) Stage(path string, data []byte) error {
+	perm := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file for %s: %w", path, err)
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write temp file for %s: %w", path, err)
+	}
+	// 确保数据真正写到磁盘，避免重命名后出现空文件
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to sync temp file for %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to close temp file for %s: %w", path, err)
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to set permissions for %s: %w", path, err)
+	}
+
+	tx.writes = append(tx.writes, &pendingWrite{path: path, tmpPath: tmpPath})
+	return nil
+}
+
+// Commit 依次用临时文件替换目标文件，失败时恢复所有已替换的原文件
+func (tx *fileTransaction) Commit() error {
+	for i, w := range tx.writes {
+		if err := tx.commitOne(w); err != nil {
+			tx.undo(i)
+			tx.Rollback()
+			return err
+		}
+	}
+
+	// 全部成功后清理回滚副本
+	for _, w := range tx.writes {
+		if w.origPath != "" {
+			os.Remove(w.origPath)
+		}
+	}
+	tx.writes = nil
+	return nil
+}
+
+// commitOne 提交单个文件：先为原文件留一份回滚副本，再把临时文件直接重命名到目标位置
+func (tx *fileTransaction) commitOne(w *pendingWrite) error {
+	if _, err := os.Stat(w.path); err == nil {
+		origPath := w.tmpPath + ".orig"
+		if err := keepOriginal(w.path, origPath); err != nil {
+			os.Remove(origPath)
+			return fmt.Errorf("failed to keep a copy of %s: %w", w.path, err)
+		}
+		w.origPath = origPath
+	}
+
+	if err := os.Rename(w.tmpPath, w.path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", w.path, err)
+	}
+	w.tmpPath = ""
+	return nil
+}
+
+// keepOriginal 为path留下回滚副本：优先使用硬链接，文件系统不支持时复制内容和权限
+func keepOriginal(path, origPath string) error {
+	if err := os.Link(path, origPath); err == nil {
+		return nil
+	}
+
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+	dst, err := os.OpenFile(origPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+// undo 撤销前n个已提交的文件以及第n个提交到一半的文件
+func (tx *fileTransaction) undo(n int) {
+	for i := n; i >= 0; i-- {
+		w := tx.writes[i]
+		switch {
+		case w.tmpPath != "":
+			// 新内容还没有就位，目标文件未被改动，只需丢弃回滚副本
+			if w.origPath != "" {
+				os.Remove(w.origPath)
+			}
+		case w.origPath != "":
+			// 用回滚副本直接替换新内容
+			os.Rename(w.origPath, w.path)
+		default:
+			// 原来没有这个文件
+			os.Remove(w.path)
+		}
+		w.origPath = ""
+	}
+}
+
+// Rollback 放弃尚未提交的写入，删除所有临时文件
+func (tx *fileTransaction) Rollback() {
+	for _, w := range tx.writes {
+		if w.tmpPath != "" {
+			os.Remove(w.tmpPath)
+		}
+	}
+	tx.writes = nil
+}
+
+// writeFileAtomic 通过临时文件+重命名写入单个文件
+func writeFileAtomic(path string, data []byte) error {
+	tx := newFileTransaction()
+	if err := tx.Stage(path, data); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
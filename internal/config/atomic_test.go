@@ -0,0 +1,170 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestFileTransactionCommit(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "a.yaml")
+	fresh := filepath.Join(dir, "a.conf")
+	writeFile(t, existing, "old")
+
+	tx := newFileTransaction()
+	for path, data := range map[string]string{existing: "new yaml", fresh: "new conf"} {
+		if err := tx.Stage(path, []byte(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := readFile(t, existing); got != "old" {
+		t.Fatalf("staging changed the target: %q", got)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := readFile(t, existing); got != "new yaml" {
+		t.Errorf("a.yaml = %q", got)
+	}
+	if got := readFile(t, fresh); got != "new conf" {
+		t.Errorf("a.conf = %q", got)
+	}
+	assertOnlyFiles(t, dir, "a.conf", "a.yaml")
+}
+
+func TestFileTransactionRollback(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "a.conf")
+	second := filepath.Join(dir, "a.yaml")
+	writeFile(t, first, "old conf")
+
+	tx := newFileTransaction()
+	if err := tx.Stage(first, []byte("new conf")); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Stage(second, []byte("new yaml")); err != nil {
+		t.Fatal(err)
+	}
+	// 第二个文件的临时文件丢失，提交到一半失败
+	os.Remove(tx.writes[1].tmpPath)
+
+	if err := tx.Commit(); err == nil {
+		t.Fatal("expected commit to fail")
+	}
+	if got := readFile(t, first); got != "old conf" {
+		t.Errorf("a.conf was not rolled back: %q", got)
+	}
+	if _, err := os.Stat(second); !os.IsNotExist(err) {
+		t.Errorf("a.yaml should not exist after rollback")
+	}
+	assertOnlyFiles(t, dir, "a.conf")
+}
+
+func TestFileTransactionKeepsMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes are not preserved on Windows")
+	}
+	dir := t.TempDir()
+	tests := []struct {
+		name string
+		mode os.FileMode // 已有文件的权限，为0时文件不存在
+		want os.FileMode
+	}{
+		{"new file", 0, 0644},
+		{"executable", 0755, 0755},
+		{"restricted", 0600, 0600},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.name)
+			if tt.mode != 0 {
+				writeFile(t, path, "old")
+				if err := os.Chmod(path, tt.mode); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := writeFileAtomic(path, []byte("new")); err != nil {
+				t.Fatal(err)
+			}
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := info.Mode().Perm(); got != tt.want {
+				t.Errorf("mode = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileTransactionUndoCommitted(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "a.conf")
+	second := filepath.Join(dir, "a.yaml")
+	writeFile(t, first, "old conf")
+	writeFile(t, second, "old yaml")
+
+	tx := newFileTransaction()
+	if err := tx.Stage(first, []byte("new conf")); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Stage(second, []byte("new yaml")); err != nil {
+		t.Fatal(err)
+	}
+	// 第一个文件已替换后第二个失败，第一个文件从回滚副本恢复
+	os.Remove(tx.writes[1].tmpPath)
+
+	if err := tx.Commit(); err == nil {
+		t.Fatal("expected commit to fail")
+	}
+	if got := readFile(t, first); got != "old conf" {
+		t.Errorf("a.conf was not rolled back: %q", got)
+	}
+	if got := readFile(t, second); got != "old yaml" {
+		t.Errorf("a.yaml changed: %q", got)
+	}
+	assertOnlyFiles(t, dir, "a.conf", "a.yaml")
+}
+
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// assertOnlyFiles 目录中只有names这些文件，没有残留的临时文件
+func assertOnlyFiles(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, entry := range entries {
+		got = append(got, entry.Name())
+	}
+	if len(got) != len(names) {
+		t.Fatalf("files in %s = %v, want %v", dir, got, names)
+	}
+	for i := range names {
+		if got[i] != names[i] {
+			t.Fatalf("files in %s = %v, want %v", dir, got, names)
+		}
+	}
+}
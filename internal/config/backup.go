@@ -0,0 +1,238 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"configcraft/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// backupManifestName 每组备份中记录各文件的清单
+const backupManifestName = "manifest.yaml"
+
+// Backup 一次保存前留下的历史版本：YAML及其输出文件作为一组一起备份
+type Backup struct {
+	Index    int       // 1为最近一次
+	Path     string    // 这组备份所在的目录
+	ModTime  time.Time // 备份对应版本的保存时间
+	Files    []string  // 组中各文件的原路径
+	Complete bool      // 组中的文件当时都存在且副本完整，只有完整的版本才能恢复
+}
+
+// backupManifest 一组备份的清单
+type backupManifest struct {
+	SavedAt time.Time    `yaml:"saved_at"`
+	Files   []backupFile `yaml:"files"`
+}
+
+// backupFile 组中的一个文件
+type backupFile struct {
+	Path   string `yaml:"path"`             // 原文件相对于YAML所在目录的路径，使用/分隔
+	Backup string `yaml:"backup,omitempty"` // 组目录中的副本文件名，原文件当时不存在时为空
+}
+
+// backupRoot 返回YAML配置的备份目录，例如 dhf_config.yaml.bak/，其中每个版本一个子目录
+func backupRoot(yamlPath string) string {
+	return yamlPath + ".bak"
+}
+
+// backupSetPath 返回第n组备份的目录，例如 dhf_config.yaml.bak/1
+func backupSetPath(yamlPath string, n int) string {
+	return filepath.Join(backupRoot(yamlPath), strconv.Itoa(n))
+}
+
+// stagedBackup 提交前准备好的一组备份，提交成功后才加入备份链
+type stagedBackup struct {
+	yamlPath string
+	dir      string // 临时目录
+}
+
+// stageBackup 把paths中各文件的当前内容复制到临时目录，作为以yamlPath为准的一组备份
+// 只生成输出文件时paths中没有YAML，YAML同样加入这组备份，使每组都能把YAML和输出文件一起恢复；
+// keep<=0或paths中的文件都还不存在时不备份，返回nil
+func stageBackup(yamlPath string, paths []string, keep int) (*stagedBackup, error) {
+	if keep <= 0 {
+		return nil, nil
+	}
+	exists, hasYaml := false, false
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil {
+			exists = true
+		}
+		if path == yamlPath {
+			hasYaml = true
+		}
+	}
+	if !exists {
+		return nil, nil
+	}
+	if !hasYaml {
+		paths = append(paths, yamlPath)
+	}
+
+	if err := os.MkdirAll(backupRoot(yamlPath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	dir, err := os.MkdirTemp(backupRoot(yamlPath), ".tmp-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	staged := &stagedBackup{yamlPath: yamlPath, dir: dir}
+
+	// 保存时间取YAML的修改时间，便于在恢复对话框中辨认版本
+	manifest := backupManifest{SavedAt: time.Now()}
+	if info, err := os.Stat(yamlPath); err == nil {
+		manifest.SavedAt = info.ModTime()
+	}
+	for i, path := range paths {
+		file := backupFile{Path: relativeTo(filepath.Dir(yamlPath), path)}
+		data, err := os.ReadFile(path)
+		if err == nil {
+			file.Backup = fmt.Sprintf("%d-%s", i, filepath.Base(path))
+			err = os.WriteFile(filepath.Join(dir, file.Backup), data, 0644)
+		} else if os.IsNotExist(err) {
+			err = nil
+		}
+		if err != nil {
+			staged.discard()
+			return nil, fmt.Errorf("failed to back up %s: %w", path, err)
+		}
+		manifest.Files = append(manifest.Files, file)
+	}
+
+	data, err := yaml.Marshal(manifest)
+	if err == nil {
+		err = os.WriteFile(filepath.Join(dir, backupManifestName), data, 0644)
+	}
+	if err != nil {
+		staged.discard()
+		return nil, fmt.Errorf("failed to write backup manifest: %w", err)
+	}
+	return staged, nil
+}
+
+// discard 提交失败时丢弃准备好的备份，已有的备份链保持不变
+func (s *stagedBackup) discard() {
+	if s != nil {
+		os.RemoveAll(s.dir)
+	}
+}
+
+// keep 提交成功后把这组备份作为第1组，已有的各组依次后移，超出keep的最旧一组被删除
+func (s *stagedBackup) keep(keep int) error {
+	if s == nil {
+		return nil
+	}
+	os.RemoveAll(backupSetPath(s.yamlPath, keep))
+	for n := keep - 1; n >= 1; n-- {
+		if _, err := os.Stat(backupSetPath(s.yamlPath, n)); err == nil {
+			if err := os.Rename(backupSetPath(s.yamlPath, n), backupSetPath(s.yamlPath, n+1)); err != nil {
+				s.discard()
+				return fmt.Errorf("files were saved, but rotating backups failed: %w", err)
+			}
+		}
+	}
+	if err := os.Rename(s.dir, backupSetPath(s.yamlPath, 1)); err != nil {
+		s.discard()
+		return fmt.Errorf("files were saved, but keeping the backup failed: %w", err)
+	}
+	return nil
+}
+
+// relativeTo path相对于dir的路径（/分隔），无法转换时保留绝对路径
+func relativeTo(dir, path string) string {
+	if rel, err := filepath.Rel(dir, path); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
+
+// readBackupSet 读取第n组备份的清单
+func readBackupSet(yamlPath string, n int) (backupManifest, error) {
+	var manifest backupManifest
+	data, err := os.ReadFile(filepath.Join(backupSetPath(yamlPath, n), backupManifestName))
+	if err != nil {
+		return manifest, err
+	}
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return manifest, fmt.Errorf("failed to parse backup manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+// originalPath 清单中记录的原文件路径
+func (f backupFile) originalPath(yamlPath string) string {
+	path := filepath.FromSlash(f.Path)
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(filepath.Dir(yamlPath), path)
+}
+
+// ListBackups 列出YAML配置文件的所有历史版本，最近的在前
+func (p *Parser) ListBackups(yamlPath string) []Backup {
+	var backups []Backup
+	for n := 1; ; n++ {
+		manifest, err := readBackupSet(yamlPath, n)
+		if err != nil {
+			break
+		}
+		backup := Backup{
+			Index:    n,
+			Path:     backupSetPath(yamlPath, n),
+			ModTime:  manifest.SavedAt,
+			Complete: len(manifest.Files) > 0,
+		}
+		for _, file := range manifest.Files {
+			backup.Files = append(backup.Files, file.originalPath(yamlPath))
+			if file.Backup == "" {
+				backup.Complete = false
+			} else if _, err := os.Stat(filepath.Join(backup.Path, file.Backup)); err != nil {
+				backup.Complete = false
+			}
+		}
+		backups = append(backups, backup)
+	}
+	return backups
+}
+
+// RestoreBackup 将YAML及其输出文件一起恢复到第index组备份
+// 组中有文件当时不存在或副本缺失时拒绝恢复，避免把旧的YAML与当前的输出文件混在一起；
+// 恢复前会先把当前版本加入备份，因此恢复操作本身也可以撤销；恢复引起的值变化记入变更记录
+func (p *Parser) RestoreBackup(yamlPath string, index int) error {
+	manifest, err := readBackupSet(yamlPath, index)
+	if err != nil {
+		return fmt.Errorf("failed to read backup #%d: %w", index, err)
+	}
+
+	contents := make(map[string][]byte)
+	for _, file := range manifest.Files {
+		path := file.originalPath(yamlPath)
+		if file.Backup == "" {
+			return fmt.Errorf("backup #%d is incomplete: %s did not exist at that version", index, filepath.Base(path))
+		}
+		data, err := os.ReadFile(filepath.Join(backupSetPath(yamlPath, index), file.Backup))
+		if err != nil {
+			return fmt.Errorf("backup #%d is incomplete: %w", index, err)
+		}
+		contents[path] = data
+	}
+	if len(contents) == 0 {
+		return fmt.Errorf("backup #%d is empty", index)
+	}
+
+	if data, ok := contents[yamlPath]; ok {
+		var restored models.UserConfig
+		if err := yaml.Unmarshal(data, &restored); err == nil {
+			if err := p.addChangeLog(contents, restored.Values, yamlPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	return p.commitFiles(yamlPath, contents)
+}
@@ -0,0 +1,177 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"configcraft/internal/models"
+)
+
+// saveVersion 以value作为配置值保存YAML和conf
+func saveVersion(t *testing.T, p *Parser, yamlPath string, value int) {
+	t.Helper()
+	config := &models.UserConfig{Values: map[string]interface{}{"basic.level": value}}
+	if err := p.OverwriteConfigWithConf(config, yamlPath, OutputFull); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBackupSetsPairYamlAndConf(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "cfg.yaml")
+	writeFile(t, yamlPath, "values:\n    basic.level: 0\n")
+
+	p := NewParser()
+	p.SetBackupCount(5)
+	saveVersion(t, p, yamlPath, 1) // 备份#2：原YAML，当时还没有conf
+	saveVersion(t, p, yamlPath, 2) // 备份#1：第1次保存的YAML和conf
+	saveVersion(t, p, yamlPath, 3)
+
+	backups := p.ListBackups(yamlPath)
+	if len(backups) != 3 {
+		t.Fatalf("got %d backups, want 3", len(backups))
+	}
+	for i, want := range []bool{true, true, false} {
+		if backups[i].Complete != want {
+			t.Errorf("backup #%d complete = %v, want %v", backups[i].Index, backups[i].Complete, want)
+		}
+		if len(backups[i].Files) != 2 {
+			t.Errorf("backup #%d files = %v, want YAML and conf", backups[i].Index, backups[i].Files)
+		}
+	}
+
+	if err := p.RestoreBackup(yamlPath, 3); err == nil || !strings.Contains(err.Error(), "incomplete") {
+		t.Fatalf("restoring an incomplete backup: err = %v", err)
+	}
+
+	if err := p.RestoreBackup(yamlPath, 2); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, yamlPath); !strings.Contains(got, "basic.level: 1") {
+		t.Errorf("restored YAML = %q", got)
+	}
+	if got := readFile(t, ConfPathFor(yamlPath)); !strings.Contains(got, "=1\n") {
+		t.Errorf("restored conf does not match the YAML:\n%s", got)
+	}
+
+	// 恢复前的版本成为#1，恢复本身可以撤销
+	if err := p.RestoreBackup(yamlPath, 1); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, yamlPath); !strings.Contains(got, "basic.level: 3") {
+		t.Errorf("undoing the restore gave %q", got)
+	}
+}
+
+func TestBackupRotationKeepsLimit(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "cfg.yaml")
+
+	p := NewParser()
+	p.SetBackupCount(2)
+	for value := 1; value <= 5; value++ {
+		saveVersion(t, p, yamlPath, value)
+	}
+
+	backups := p.ListBackups(yamlPath)
+	if len(backups) != 2 {
+		t.Fatalf("got %d backups, want 2", len(backups))
+	}
+	for i, want := range []string{"basic.level: 4", "basic.level: 3"} {
+		data, err := os.ReadFile(filepath.Join(backups[i].Path, "1-cfg.yaml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(data), want) {
+			t.Errorf("backup #%d = %q, want %s", backups[i].Index, data, want)
+		}
+	}
+}
+
+func TestFailedCommitKeepsBackups(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "cfg.yaml")
+
+	p := NewParser()
+	p.SetBackupCount(3)
+	saveVersion(t, p, yamlPath, 1)
+	saveVersion(t, p, yamlPath, 2)
+	before := p.ListBackups(yamlPath)
+
+	files := map[string][]byte{
+		yamlPath: []byte("values: {}\n"),
+		filepath.Join(dir, "missing", "out.conf"): []byte("x"), // 目录不存在，写入失败
+	}
+	if err := p.commitFiles(yamlPath, files); err == nil {
+		t.Fatal("expected commit to fail")
+	}
+
+	after := p.ListBackups(yamlPath)
+	if len(after) != len(before) || after[0].ModTime != before[0].ModTime {
+		t.Errorf("backups changed after a failed commit: %v -> %v", before, after)
+	}
+	if got := readFile(t, yamlPath); !strings.Contains(got, "basic.level: 2") {
+		t.Errorf("YAML changed after a failed commit: %q", got)
+	}
+	entries, _ := os.ReadDir(backupRoot(yamlPath))
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".tmp-") {
+			t.Errorf("staged backup %s was not discarded", entry.Name())
+		}
+	}
+}
+
+func TestOutputOnlyBackupIncludesYaml(t *testing.T) {
+	dir := t.TempDir()
+	p := NewParser()
+	if err := p.LoadSchema(writeSchema(t, dir, testSchema)); err != nil {
+		t.Fatal(err)
+	}
+	p.SetBackupCount(5)
+	yamlPath := filepath.Join(dir, "cfg.yaml")
+	confPath := ConfPathFor(yamlPath)
+	saveVersion(t, p, yamlPath, 1)
+
+	// 只重新生成conf，YAML不变
+	config, err := p.LoadUserConfig(yamlPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.Values["basic.level"] = 2
+	outputs := []models.ProjectOutput{{Path: confPath}}
+	if err := p.GenerateOutputs(config, outputs, models.GeneratorSettings{}); err != nil {
+		t.Fatal(err)
+	}
+
+	backups := p.ListBackups(yamlPath)
+	if len(backups) != 1 {
+		t.Fatalf("got %d backups, want 1", len(backups))
+	}
+	if want := []string{confPath, yamlPath}; !backups[0].Complete || !reflect.DeepEqual(backups[0].Files, want) {
+		t.Fatalf("backup = %+v, want a complete set of %v", backups[0], want)
+	}
+
+	// 恢复后YAML与conf仍然一致
+	if err := p.RestoreBackup(yamlPath, 1); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, confPath); !strings.Contains(got, "_BASIC_LEVEL=1\n") {
+		t.Errorf("restored conf:\n%s", got)
+	}
+	if got := readFile(t, yamlPath); !strings.Contains(got, "basic.level: 1") {
+		t.Errorf("restored YAML = %q", got)
+	}
+
+	// 输出文件还不存在时不留下无法恢复的备份
+	fresh := []models.ProjectOutput{{Path: filepath.Join(dir, "new.conf")}}
+	before := len(p.ListBackups(yamlPath))
+	if err := p.GenerateOutputs(config, fresh, models.GeneratorSettings{}); err != nil {
+		t.Fatal(err)
+	}
+	if after := len(p.ListBackups(yamlPath)); after != before {
+		t.Errorf("generating a new output added a backup: %d -> %d", before, after)
+	}
+}
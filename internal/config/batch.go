@@ -0,0 +1,223 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"configcraft/internal/models"
+)
+
+// 批量处理中单个配置的结果状态
+const (
+	BatchPassed  = "passed"  // 校验通过并已生成
+	BatchWarning = "warning" // 有警告，已生成
+	BatchFailed  = "failed"  // 加载/校验/生成失败，未生成
+)
+
+// BatchOptions 批量生成的选项
+type BatchOptions struct {
+	OutputDir    string // 输出目录，为空时输出到各配置文件旁
+	ValidateOnly bool   // 只校验，不生成输出
+	Format       string // 输出格式，为空时为conf；其他格式由schema的templates定义
+	Template     string // 生成模板文件，指定时忽略Format
+	Mode         string // 输出模式，为空时输出配置中的所有值
+	Backups      int    // 覆盖输出文件前保留的历史版本数
+	Force        bool   // 覆盖在上次生成后被手工修改的conf输出文件
+}
+
+// BatchResult 批量处理中一个配置的结果
+type BatchResult struct {
+	ConfigPath string
+	OutputPath string // 未生成时为空
+	Issues     []Issue
+	Err        error // 加载或生成失败的原因
+	Duration   time.Duration
+}
+
+// Status 结果状态：BatchPassed、BatchWarning 或 BatchFailed
+func (r BatchResult) Status() string {
+	switch {
+	case r.Err != nil || HasErrors(r.Issues):
+		return BatchFailed
+	case len(r.Issues) > 0:
+		return BatchWarning
+	}
+	return BatchPassed
+}
+
+// BatchSummary 批量处理的统计
+type BatchSummary struct {
+	Total    int `json:"total"`
+	Passed   int `json:"passed"`
+	Warnings int `json:"warnings"`
+	Failed   int `json:"failed"`
+}
+
+// SummarizeBatch 统计各状态的配置数
+func SummarizeBatch(results []BatchResult) BatchSummary {
+	summary := BatchSummary{Total: len(results)}
+	for _, result := range results {
+		switch result.Status() {
+		case BatchPassed:
+			summary.Passed++
+		case BatchWarning:
+			summary.Warnings++
+		case BatchFailed:
+			summary.Failed++
+		}
+	}
+	return summary
+}
+
+// ExpandConfigPaths 把目录或glob模式展开为配置文件列表
+// 目录会递归查找其中的YAML配置文件（跳过schema文件）；项目文件和excludes中的文件会被跳过
+func ExpandConfigPaths(patterns []string, excludes ...string) ([]string, error) {
+	skip := make(map[string]bool)
+	for _, exclude := range excludes {
+		if abs, err := filepath.Abs(exclude); err == nil {
+			skip[abs] = true
+		}
+	}
+
+	seen := make(map[string]bool)
+	var paths []string
+	add := func(path string) {
+		abs, err := filepath.Abs(path)
+		if err != nil || seen[abs] || skip[abs] || IsProjectFile(abs) {
+			return
+		}
+		seen[abs] = true
+		paths = append(paths, abs)
+	}
+
+	for _, pattern := range patterns {
+		if info, err := os.Stat(pattern); err == nil && info.IsDir() {
+			err := filepath.WalkDir(pattern, func(path string, entry os.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				ext := strings.ToLower(filepath.Ext(path))
+				if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+					return nil
+				}
+				// 目录中可能同时存放schema文件
+				if NewParser().LoadSchema(path) == nil {
+					return nil
+				}
+				add(path)
+				return nil
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to scan %s: %w", pattern, err)
+			}
+			continue
+		}
+
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %s: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no files match %s", pattern)
+		}
+		for _, match := range matches {
+			add(match)
+		}
+	}
+
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no config files found")
+	}
+	return paths, nil
+}
+
+// batchOutputExt 批量生成的输出文件扩展名
+// 取自模板文件名中.tmpl之前的扩展名（例如dhf_config.h.tmpl生成.h），没有时使用格式名，内置conf模板生成.conf
+func batchOutputExt(format, templatePath string) string {
+	if templatePath != "" {
+		if ext := filepath.Ext(strings.TrimSuffix(filepath.Base(templatePath), ".tmpl")); ext != "" {
+			return ext
+		}
+	}
+	if format == "" {
+		format = FormatConf
+	}
+	return "." + format
+}
+
+// RunBatch 使用同一个schema校验并生成多个配置
+// 输出与项目构建一样经过GenerateOutputs，支持schema中的模板格式和单独指定的模板；
+// 有错误的配置不会生成输出；输出文件在上次生成后被手工修改时该配置失败，除非指定了Force；
+// progress（可为nil）在每个配置处理完后调用
+func RunBatch(schemaPath string, configPaths []string, options BatchOptions, progress func(done int, result BatchResult)) ([]BatchResult, error) {
+	parser := NewParser()
+	parser.SetBackupCount(options.Backups)
+	if err := parser.LoadSchema(schemaPath); err != nil {
+		return nil, err
+	}
+	schema := parser.GetSchema()
+
+	templatePath := options.Template
+	if templatePath == "" {
+		var err error
+		if templatePath, err = parser.templateFor(options.Format); err != nil {
+			return nil, err
+		}
+	}
+	ext := batchOutputExt(options.Format, templatePath)
+	settings := models.GeneratorSettings{Format: options.Format, Mode: options.Mode, Backups: options.Backups}
+	generate := parser.GenerateOutputs
+	if options.Force {
+		generate = parser.OverwriteOutputs
+	}
+
+	if options.OutputDir != "" && !options.ValidateOnly {
+		if err := os.MkdirAll(options.OutputDir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+
+	// 输出到同一目录时，不同目录下的同名配置会生成同一个文件
+	outputOwners := make(map[string]string)
+
+	results := make([]BatchResult, 0, len(configPaths))
+	for i, configPath := range configPaths {
+		start := time.Now()
+		result := BatchResult{ConfigPath: configPath}
+
+		outputPath := strings.TrimSuffix(configPath, filepath.Ext(configPath)) + ext
+		if options.OutputDir != "" {
+			outputPath = filepath.Join(options.OutputDir, filepath.Base(outputPath))
+		}
+		output := models.ProjectOutput{Path: outputPath, Template: options.Template}
+
+		userConfig, err := parser.LoadUserConfig(configPath)
+		switch {
+		case err != nil:
+			result.Err = err
+		case outputOwners[outputPath] != "":
+			result.Err = fmt.Errorf("output %s is already generated from %s", outputPath, outputOwners[outputPath])
+		default:
+			result.Issues = ValidateConfig(schema, userConfig)
+			if !HasErrors(result.Issues) && !options.ValidateOnly {
+				if err := generate(userConfig, []models.ProjectOutput{output}, settings); err != nil {
+					result.Err = err
+				} else {
+					result.OutputPath = outputPath
+					outputOwners[outputPath] = configPath
+				}
+			}
+		}
+
+		result.Duration = time.Since(start)
+		results = append(results, result)
+		if progress != nil {
+			progress(i+1, result)
+		}
+	}
+
+	return results, nil
+}
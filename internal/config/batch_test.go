@@ -0,0 +1,227 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testSchema 测试用的schema：basic.level为1~10的必填数字，basic.name为字符串
+const testSchema = `schema_version: "1.0"
+display_name: Test
+sections:
+  basic:
+    name: Basic
+    fields:
+      level:
+        type: number
+        label: Level
+        default: 5
+        required: true
+        min: 1
+        max: 10
+      name:
+        type: string
+        label: Name
+`
+
+// writeSchema 在dir中写入schema文件并返回其路径
+func writeSchema(t *testing.T, dir, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, "schema.yaml")
+	writeFile(t, path, content)
+	return path
+}
+
+// batchResults 覆盖三种状态的批量结果
+func batchResults() []BatchResult {
+	return []BatchResult{
+		{ConfigPath: "a.yaml", OutputPath: "a.conf", Duration: 1500 * time.Millisecond},
+		{ConfigPath: "b.yaml", OutputPath: "b.conf", Issues: []Issue{
+			{Path: "basic.extra", Severity: SeverityWarning, Message: "not in schema"},
+		}},
+		{ConfigPath: "c.yaml", Issues: []Issue{
+			{Path: "basic.level", Severity: SeverityError, Message: "out of range"},
+		}},
+		{ConfigPath: "d.yaml", Err: errors.New("broken yaml")},
+	}
+}
+
+func TestWriteBatchReport(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		check  func(t *testing.T, out string)
+	}{
+		{"text", ReportText, func(t *testing.T, out string) {
+			for _, want := range []string{
+				"STATUS", "PASSED", "WARNING", "FAILED",
+				"warning: basic.extra: not in schema",
+				"error: basic.level: out of range",
+				"error: broken yaml",
+				"4 configs: 1 passed, 1 with warnings, 2 failed",
+			} {
+				if !strings.Contains(out, want) {
+					t.Errorf("report does not contain %q:\n%s", want, out)
+				}
+			}
+		}},
+		{"default is text", "", func(t *testing.T, out string) {
+			if !strings.HasPrefix(out, "STATUS") {
+				t.Errorf("report = %q, want the text table", out)
+			}
+		}},
+		{"json", ReportJSON, func(t *testing.T, out string) {
+			var report jsonReport
+			if err := json.Unmarshal([]byte(out), &report); err != nil {
+				t.Fatal(err)
+			}
+			if report.Schema != "schema.yaml" {
+				t.Errorf("schema = %q", report.Schema)
+			}
+			if want := (BatchSummary{Total: 4, Passed: 1, Warnings: 1, Failed: 2}); report.Summary != want {
+				t.Errorf("summary = %+v, want %+v", report.Summary, want)
+			}
+			first, last := report.Results[0], report.Results[3]
+			if first.Status != BatchPassed || first.Output != "a.conf" || first.DurationMs != 1500 || first.Issues == nil {
+				t.Errorf("first result = %+v", first)
+			}
+			if last.Status != BatchFailed || last.Error != "broken yaml" || last.Output != "" {
+				t.Errorf("last result = %+v", last)
+			}
+		}},
+		{"junit", ReportJUnit, func(t *testing.T, out string) {
+			if !strings.HasPrefix(out, xml.Header) {
+				t.Errorf("report does not start with the XML header")
+			}
+			var suites junitTestSuites
+			if err := xml.Unmarshal([]byte(out), &suites); err != nil {
+				t.Fatal(err)
+			}
+			suite := suites.Suites[0]
+			if suite.Tests != 4 || suite.Failures != 2 || suite.Time != "1.500" {
+				t.Errorf("suite = %+v", suite)
+			}
+			if suite.Cases[0].Failure != nil || suite.Cases[1].Failure != nil {
+				t.Errorf("passed and warning configs must not fail")
+			}
+			if suite.Cases[1].SystemOut != "warning: basic.extra: not in schema" {
+				t.Errorf("warnings = %q", suite.Cases[1].SystemOut)
+			}
+			if failure := suite.Cases[2].Failure; failure == nil || failure.Text != "basic.level: out of range" {
+				t.Errorf("failure = %+v", failure)
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := WriteBatchReport(&out, tt.format, "schema.yaml", batchResults()); err != nil {
+				t.Fatal(err)
+			}
+			tt.check(t, out.String())
+		})
+	}
+
+	if err := WriteBatchReport(&bytes.Buffer{}, "html", "schema.yaml", nil); err == nil {
+		t.Error("unsupported format must fail")
+	}
+}
+
+func TestRunBatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		options func(dir string) BatchOptions
+		output  string // 相对于dir，为空表示不生成
+		check   func(t *testing.T, dir string)
+	}{
+		{
+			name:    "next to configs",
+			options: func(dir string) BatchOptions { return BatchOptions{} },
+			output:  "configs/good.conf",
+		},
+		{
+			name: "output directory is created",
+			options: func(dir string) BatchOptions {
+				return BatchOptions{OutputDir: filepath.Join(dir, "dist", "conf")}
+			},
+			output: "dist/conf/good.conf",
+		},
+		{
+			name: "template output",
+			options: func(dir string) BatchOptions {
+				template := filepath.Join(dir, "values.h.tmpl")
+				writeFile(t, template, "{{range $path, $value := .Values}}#define {{$path}} {{$value}}\n{{end}}")
+				return BatchOptions{OutputDir: filepath.Join(dir, "out"), Template: template}
+			},
+			output: "out/good.h",
+			check: func(t *testing.T, dir string) {
+				if got := readFile(t, filepath.Join(dir, "out", "good.h")); got != "#define basic.level 3\n" {
+					t.Errorf("template output = %q", got)
+				}
+			},
+		},
+		{
+			name: "validate only",
+			options: func(dir string) BatchOptions {
+				return BatchOptions{OutputDir: filepath.Join(dir, "dist"), ValidateOnly: true}
+			},
+			check: func(t *testing.T, dir string) {
+				if _, err := os.Stat(filepath.Join(dir, "dist")); !os.IsNotExist(err) {
+					t.Errorf("validate only must not create the output directory")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			schemaPath := writeSchema(t, dir, testSchema)
+			good := filepath.Join(dir, "configs", "good.yaml")
+			bad := filepath.Join(dir, "configs", "bad.yaml")
+			writeFile(t, good, "values:\n    basic.level: 3\n")
+			writeFile(t, bad, "values:\n    basic.level: 42\n")
+
+			results, err := RunBatch(schemaPath, []string{good, bad}, tt.options(dir), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := []string{results[0].Status(), results[1].Status()}; got[0] != BatchPassed || got[1] != BatchFailed {
+				t.Fatalf("statuses = %v", got)
+			}
+			if results[1].OutputPath != "" {
+				t.Errorf("failed config generated %s", results[1].OutputPath)
+			}
+
+			var want string
+			if tt.output != "" {
+				want = filepath.Join(dir, filepath.FromSlash(tt.output))
+				if _, err := os.Stat(want); err != nil {
+					t.Errorf("output not generated: %v", err)
+				}
+			}
+			if results[0].OutputPath != want {
+				t.Errorf("output path = %q, want %q", results[0].OutputPath, want)
+			}
+			if tt.check != nil {
+				tt.check(t, dir)
+			}
+		})
+	}
+}
+
+func TestRunBatchUnsupportedFormat(t *testing.T) {
+	dir := t.TempDir()
+	schemaPath := writeSchema(t, dir, testSchema)
+	if _, err := RunBatch(schemaPath, nil, BatchOptions{Format: "c_header"}, nil); err == nil {
+		t.Error("a format without a schema template must fail before processing")
+	}
+}
@@ -0,0 +1,160 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"configcraft/internal/models"
+)
+
+// ConfEdit conf文件中一项在生成后被手工修改的内容
+type ConfEdit struct {
+	Key       string      // conf键名
+	Path      string      // 对应的配置路径
+	Generated string      // 按上次保存的YAML生成的值，手工新增的项为空
+	Current   string      // conf中现在的值，被删除的项为空
+	Value     interface{} // 导回YAML时使用的值，被删除的项为nil
+	Removed   bool        // 该项在conf中被删除
+}
+
+// ConfModifiedError 要覆盖的conf文件在上次生成后被手工修改过
+type ConfModifiedError struct {
+	Path  string
+	Edits []ConfEdit
+}
+
+func (e *ConfModifiedError) Error() string {
+	return fmt.Sprintf("%s was modified after it was generated (%d changes)", e.Path, len(e.Edits))
+}
+
+// DetectConfEdits 找出conf文件中在上次生成后被手工修改的项
+// 校验和一致时直接认为未修改；否则与磁盘上的YAML（即上次保存的版本）本应生成的内容逐项比较，
+// 只比较配置行，注释和文件头的差异不算修改；mode为生成该conf时使用的输出模式。conf文件不存在时返回nil
+func (p *Parser) DetectConfEdits(config *models.UserConfig, yamlPath, mode string) ([]ConfEdit, error) {
+	templatePath, err := p.templateFor(FormatConf)
+	if err != nil {
+		return nil, err
+	}
+	return p.detectEdits(config, yamlPath, ConfPathFor(yamlPath), templatePath, mode)
+}
+
+// checkOutputEdits 检查outputs中conf格式的输出文件在上次生成后是否被手工修改
+// 返回第一个被修改的文件的*ConfModifiedError；其他格式和单独指定了模板的输出无法逐项比较，不检查
+func (p *Parser) checkOutputEdits(config *models.UserConfig, yamlPath string, outputs []models.ProjectOutput, settings models.GeneratorSettings) error {
+	for _, output := range outputs {
+		if output.Template != "" {
+			continue
+		}
+		format, mode := outputFormat(output, settings)
+		if format != "" && format != FormatConf {
+			continue
+		}
+		templatePath, err := p.templateFor(FormatConf)
+		if err != nil {
+			return err
+		}
+		edits, err := p.detectEdits(config, yamlPath, output.Path, templatePath, mode)
+		if err != nil {
+			return fmt.Errorf("%s: %w", output.Path, err)
+		}
+		if len(edits) > 0 {
+			return &ConfModifiedError{Path: output.Path, Edits: edits}
+		}
+	}
+	return nil
+}
+
+// detectEdits 找出conf格式的输出文件confPath中在上次生成后被手工修改的项
+// 上次生成的内容由磁盘上的yamlPath用templatePath按mode重新生成
+func (p *Parser) detectEdits(config *models.UserConfig, yamlPath, confPath, templatePath, mode string) ([]ConfEdit, error) {
+	data, err := os.ReadFile(confPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read conf file: %w", err)
+	}
+	if ok, err := VerifyChecksum(data); err == nil && ok {
+		return nil, nil
+	}
+
+	current, err := parseConfEntries(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	// YAML还不存在（如另存为到已有conf的位置）时，conf中的所有项都视为手工内容
+	previous := &models.UserConfig{Values: make(map[string]interface{})}
+	if _, err := os.Stat(yamlPath); err == nil {
+		if previous, err = p.LoadUserConfig(yamlPath); err != nil {
+			return nil, err
+		}
+	}
+	expectedData, err := p.renderTemplate(templatePath, previous, mode, confPath)
+	if err != nil {
+		return nil, err
+	}
+	expectedEntries, err := parseConfEntries(bytes.NewReader(expectedData))
+	if err != nil {
+		return nil, err
+	}
+
+	expected := make(map[string]string, len(expectedEntries))
+	for _, entry := range expectedEntries {
+		expected[entry.Key] = entry.Value
+	}
+
+	knownPaths := make([]string, 0, len(previous.Values)+len(config.Values))
+	for path := range previous.Values {
+		knownPaths = append(knownPaths, path)
+	}
+	for path := range config.Values {
+		knownPaths = append(knownPaths, path)
+	}
+	pathFor := confPathResolver(p.schema, knownPaths)
+
+	var edits []ConfEdit
+	seen := make(map[string]bool, len(current))
+	for _, entry := range current {
+		seen[entry.Key] = true
+		generated, exists := expected[entry.Key]
+		if exists && generated == entry.Value {
+			continue
+		}
+		path, _ := pathFor(entry.Key)
+		field, _ := LookupField(p.schema, path)
+		edits = append(edits, ConfEdit{
+			Key:       entry.Key,
+			Path:      path,
+			Generated: generated,
+			Current:   entry.Value,
+			Value:     parseConfValue(entry.Value, field),
+		})
+	}
+	for _, entry := range expectedEntries {
+		if !seen[entry.Key] {
+			path, _ := pathFor(entry.Key)
+			edits = append(edits, ConfEdit{Key: entry.Key, Path: path, Generated: entry.Value, Removed: true})
+		}
+	}
+
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].Path < edits[j].Path })
+	return edits, nil
+}
+
+// ApplyConfEdits 把conf中的手工修改导回配置：修改和新增的项写入值，被删除的项从配置中移除
+func ApplyConfEdits(config *models.UserConfig, edits []ConfEdit) {
+	if config.Values == nil {
+		config.Values = make(map[string]interface{})
+	}
+	for _, edit := range edits {
+		if edit.Removed {
+			delete(config.Values, edit.Path)
+		} else {
+			config.Values[edit.Path] = edit.Value
+		}
+	}
+}
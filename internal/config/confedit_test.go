@@ -0,0 +1,197 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"configcraft/internal/models"
+)
+
+func TestDetectConfEdits(t *testing.T) {
+	tests := []struct {
+		name  string
+		edit  func(conf string) string
+		edits []ConfEdit
+	}{
+		{
+			name: "untouched",
+			edit: func(conf string) string { return conf },
+		},
+		{
+			name: "comment only",
+			edit: func(conf string) string { return "# 调试时的说明\n" + conf },
+		},
+		{
+			name: "changed value",
+			edit: func(conf string) string { return strings.Replace(conf, "_BASIC_LEVEL=3", "_BASIC_LEVEL=7", 1) },
+			edits: []ConfEdit{
+				{Key: "_BASIC_LEVEL", Path: "basic.level", Generated: "3", Current: "7", Value: 7},
+			},
+		},
+		{
+			name: "added line",
+			edit: func(conf string) string { return conf + "_BASIC_NAME=lab\n" },
+			edits: []ConfEdit{
+				{Key: "_BASIC_NAME", Path: "basic.name", Current: "lab", Value: "lab"},
+			},
+		},
+		{
+			name: "removed line",
+			edit: func(conf string) string { return strings.Replace(conf, "_BASIC_LEVEL=3\n", "", 1) },
+			edits: []ConfEdit{
+				{Key: "_BASIC_LEVEL", Path: "basic.level", Generated: "3", Removed: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			p := NewParser()
+			if err := p.LoadSchema(writeSchema(t, dir, testSchema)); err != nil {
+				t.Fatal(err)
+			}
+			yamlPath := filepath.Join(dir, "cfg.yaml")
+			config := &models.UserConfig{Values: map[string]interface{}{"basic.level": 3}}
+			if err := p.SaveConfigWithConf(config, yamlPath, OutputFull); err != nil {
+				t.Fatal(err)
+			}
+			confPath := ConfPathFor(yamlPath)
+			writeFile(t, confPath, tt.edit(readFile(t, confPath)))
+
+			edits, err := p.DetectConfEdits(config, yamlPath, OutputFull)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(edits, tt.edits) {
+				t.Fatalf("edits = %+v, want %+v", edits, tt.edits)
+			}
+
+			// 有手工修改时保存被拒绝，导回后再保存得到与conf一致的配置
+			err = p.SaveConfigWithConf(config, yamlPath, OutputFull)
+			var modified *ConfModifiedError
+			if len(tt.edits) == 0 {
+				if err != nil {
+					t.Fatalf("save without hand edits failed: %v", err)
+				}
+				return
+			}
+			if !errors.As(err, &modified) || modified.Path != confPath {
+				t.Fatalf("save err = %v, want *ConfModifiedError", err)
+			}
+			ApplyConfEdits(config, modified.Edits)
+			if err := p.OverwriteConfigWithConf(config, yamlPath, OutputFull); err != nil {
+				t.Fatal(err)
+			}
+			if edits, _ := p.DetectConfEdits(config, yamlPath, OutputFull); len(edits) != 0 {
+				t.Errorf("edits after import = %+v", edits)
+			}
+		})
+	}
+}
+
+func TestDetectConfEditsWithoutConf(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "cfg.yaml")
+	writeFile(t, yamlPath, "values:\n    basic.level: 3\n")
+
+	edits, err := NewParser().DetectConfEdits(&models.UserConfig{}, yamlPath, OutputFull)
+	if err != nil || edits != nil {
+		t.Errorf("DetectConfEdits = %v, %v; want nil", edits, err)
+	}
+	if _, err := os.Stat(ConfPathFor(yamlPath)); !os.IsNotExist(err) {
+		t.Error("conf file must not be created")
+	}
+}
+
+func TestOutputsRefuseHandEdits(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(p *Parser, config *models.UserConfig, yamlPath string, outputs []models.ProjectOutput) error
+		force bool // 不检查手工修改
+	}{
+		{"generate", func(p *Parser, config *models.UserConfig, _ string, outputs []models.ProjectOutput) error {
+			return p.GenerateOutputs(config, outputs, models.GeneratorSettings{})
+		}, false},
+		{"save", func(p *Parser, config *models.UserConfig, yamlPath string, outputs []models.ProjectOutput) error {
+			return p.SaveConfigWithOutputs(config, yamlPath, outputs, models.GeneratorSettings{})
+		}, false},
+		{"overwrite outputs", func(p *Parser, config *models.UserConfig, _ string, outputs []models.ProjectOutput) error {
+			return p.OverwriteOutputs(config, outputs, models.GeneratorSettings{})
+		}, true},
+		{"overwrite config", func(p *Parser, config *models.UserConfig, yamlPath string, outputs []models.ProjectOutput) error {
+			return p.OverwriteConfigWithOutputs(config, yamlPath, outputs, models.GeneratorSettings{})
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			p := NewParser()
+			if err := p.LoadSchema(writeSchema(t, dir, testSchema)); err != nil {
+				t.Fatal(err)
+			}
+			yamlPath := filepath.Join(dir, "cfg.yaml")
+			confPath := filepath.Join(dir, "out", "cfg.conf")
+			outputs := []models.ProjectOutput{{Path: confPath}}
+			config := &models.UserConfig{Values: map[string]interface{}{"basic.level": 3}}
+			if err := os.MkdirAll(filepath.Dir(confPath), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := p.OverwriteConfigWithOutputs(config, yamlPath, outputs, models.GeneratorSettings{}); err != nil {
+				t.Fatal(err)
+			}
+			edited := strings.Replace(readFile(t, confPath), "_BASIC_LEVEL=3", "_BASIC_LEVEL=7", 1)
+			writeFile(t, confPath, edited)
+
+			config.Values["basic.level"] = 4
+			err := tt.write(p, config, yamlPath, outputs)
+			if tt.force {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := readFile(t, confPath); !strings.Contains(got, "_BASIC_LEVEL=4\n") {
+					t.Errorf("conf was not overwritten:\n%s", got)
+				}
+				return
+			}
+
+			var modified *ConfModifiedError
+			if !errors.As(err, &modified) || modified.Path != confPath || len(modified.Edits) != 1 {
+				t.Fatalf("err = %v, want *ConfModifiedError for %s", err, confPath)
+			}
+			if got := readFile(t, confPath); got != edited {
+				t.Errorf("hand-edited conf was overwritten:\n%s", got)
+			}
+		})
+	}
+}
+
+func TestBuildProjectConfigForce(t *testing.T) {
+	dir := t.TempDir()
+	schemaPath := writeSchema(t, dir, testSchema)
+	yamlPath := filepath.Join(dir, "cfg.yaml")
+	writeFile(t, yamlPath, "values:\n    basic.level: 3\n")
+	cfg := models.ProjectConfig{Name: "cfg", Path: yamlPath, Schema: schemaPath, Outputs: []models.ProjectOutput{{Path: ConfPathFor(yamlPath)}}}
+
+	if _, err := BuildProjectConfig(cfg, false); err != nil {
+		t.Fatal(err)
+	}
+	confPath := ConfPathFor(yamlPath)
+	writeFile(t, confPath, readFile(t, confPath)+"_BASIC_NAME=lab\n")
+
+	var modified *ConfModifiedError
+	if _, err := BuildProjectConfig(cfg, false); !errors.As(err, &modified) {
+		t.Fatalf("build err = %v, want *ConfModifiedError", err)
+	}
+	if _, err := BuildProjectConfig(cfg, true); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, confPath); strings.Contains(got, "_BASIC_NAME") {
+		t.Errorf("forced build kept the hand edit:\n%s", got)
+	}
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"configcraft/internal/models"
+)
+
+// ConfEntry conf文件中的一行配置
+type ConfEntry struct {
+	Key   string // 例如 _BASIC_IC_MODEL
+	Value string // 原始文本值
+	Line  int    // 所在行号，从1开始
+}
+
+// ReadConfFile 读取conf文件中的所有配置行，忽略注释和空行
+func ReadConfFile(filePath string) ([]ConfEntry, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read conf file: %w", err)
+	}
+	defer file.Close()
+
+	return parseConfEntries(file)
+}
+
+// parseConfEntries 解析conf内容中的配置行
+func parseConfEntries(r io.Reader) ([]ConfEntry, error) {
+	var entries []ConfEntry
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid conf line %d: %q", lineNo, line)
+		}
+		entries = append(entries, ConfEntry{
+			Key:   strings.TrimSpace(key),
+			Value: strings.TrimSpace(value),
+			Line:  lineNo,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read conf file: %w", err)
+	}
+
+	return entries, nil
+}
+
+// ImportConfFile 将conf文件还原为用户配置
+// conf键名按schema的命名规则由YAML路径生成且不可逆，因此通过schema字段和knownPaths（如当前配置已有的键）
+// 建立反向映射；无法匹配的键按命名规则还原为一级键导入（再次生成时得到相同的conf键），并在返回值中列出
+func ImportConfFile(filePath string, schema *models.Schema, knownPaths []string) (*models.UserConfig, []string, error) {
+	entries, err := ReadConfFile(filePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pathFor := confPathResolver(schema, knownPaths)
+	config := &models.UserConfig{Values: make(map[string]interface{})}
+	var unmatched []string
+	for _, entry := range entries {
+		path, matched := pathFor(entry.Key)
+		if !matched {
+			unmatched = append(unmatched, entry.Key)
+		}
+
+		field, _ := LookupField(schema, path)
+		config.Values[path] = parseConfValue(entry.Value, field)
+	}
+
+	return config, unmatched, nil
+}
+
+// confPathResolver 返回把conf键名还原为配置路径的函数，matched为false表示按命名规则还原为一级键
+func confPathResolver(schema *models.Schema, knownPaths []string) func(key string) (path string, matched bool) {
+	pathByKey := make(map[string]string)
+	for _, path := range knownPaths {
+		pathByKey[OutputKey(schema, path)] = path
+	}
+	// schema中的字段优先于其他来源
+	ForEachField(schema, func(path string, field models.ConfigField) {
+		pathByKey[OutputKey(schema, path)] = path
+	})
+
+	var naming models.NamingRule
+	if schema != nil {
+		naming = schema.Naming
+	}
+
+	return func(key string) (string, bool) {
+		if path, exists := pathByKey[key]; exists {
+			return path, true
+		}
+		return pathFromOutputKey(naming, key), false
+	}
+}
+
+// parseConfValue 按字段类型把conf文本值还原为YAML值；没有schema信息时按字面推断
+func parseConfValue(raw string, field models.ConfigField) interface{} {
+	switch field.Type {
+	case "text":
+		return raw
+	case "boolean":
+		if b, err := strconv.ParseBool(raw); err == nil {
+			return b
+		}
+		return raw
+	case "number":
+		if n, err := strconv.Atoi(raw); err == nil {
+			return n
+		}
+		return raw
+	case "flags":
+		return parseConfFlags(raw, field)
+	case "color":
+		return parseConfColor(raw)
+	case "path":
+		return raw
+	case "map":
+		return parseConfMap(raw)
+	}
+
+	// select/combo等类型的值可能是数字或字符串，与选项值保持一致
+	for _, option := range field.Options {
+		if fmt.Sprintf("%v", option.Value) == raw {
+			return option.Value
+		}
+	}
+
+	if raw == "true" || raw == "false" {
+		return raw == "true"
+	}
+	if n, err := strconv.Atoi(raw); err == nil {
+		return n
+	}
+	return raw
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"configcraft/internal/models"
+)
+
+// fieldTypesSchema 各种需要特殊格式的字段
+const fieldTypesSchema = `sections:
+  audio:
+    fields:
+      features:
+        type: flags
+        format: mask
+        options:
+          - {value: 1, label: ANC}
+          - {value: 2, label: Transparency}
+          - {value: 4, label: Spatial}
+      codecs:
+        type: flags
+        options:
+          - {value: SBC, label: SBC}
+          - {value: AAC, label: AAC}
+          - {value: LDAC, label: LDAC}
+      tint:
+        type: color
+        format: hex
+      accent:
+        type: color
+      names:
+        type: map
+`
+
+func TestConfImportRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		// value 写入配置的值，imported 从conf导回的值（为nil时与value相同）
+		value    interface{}
+		imported interface{}
+		conf     string // conf中的行
+	}{
+		{"flags mask", "audio.features", []interface{}{1, 4}, nil, "_AUDIO_FEATURES=0x5"},
+		{"flags mask empty", "audio.features", []interface{}{}, nil, "_AUDIO_FEATURES=0x0"},
+		{"flags list", "audio.codecs", []interface{}{"SBC", "LDAC"}, nil, "_AUDIO_CODECS=SBC,LDAC"},
+		{"color hex", "audio.tint", "#336699", nil, "_AUDIO_TINT=0x336699"},
+		{"color html lower case", "audio.accent", "#ff8000", "#FF8000", "_AUDIO_ACCENT=#FF8000"},
+		{"map", "audio.names", map[string]interface{}{"zh": "耳机", "en": "Headset"}, nil, "_AUDIO_NAMES=en=Headset,zh=耳机"},
+		{"map empty", "audio.names", map[string]interface{}{}, nil, "_AUDIO_NAMES="},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			p := NewParser()
+			if err := p.LoadSchema(writeSchema(t, dir, fieldTypesSchema)); err != nil {
+				t.Fatal(err)
+			}
+			confPath := filepath.Join(dir, "cfg.conf")
+			config := &models.UserConfig{Values: map[string]interface{}{tt.path: tt.value}}
+			if err := p.GenerateConfFile(config, confPath, OutputFull); err != nil {
+				t.Fatal(err)
+			}
+			if conf := readFile(t, confPath); !strings.Contains(conf, tt.conf+"\n") {
+				t.Fatalf("conf does not contain %s:\n%s", tt.conf, conf)
+			}
+
+			imported, unmatched, err := ImportConfFile(confPath, p.GetSchema(), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(unmatched) != 0 {
+				t.Errorf("unmatched keys: %v", unmatched)
+			}
+			want := tt.imported
+			if want == nil {
+				want = tt.value
+			}
+			if got := imported.Values[tt.path]; !reflect.DeepEqual(got, want) {
+				t.Errorf("imported %s = %#v, want %#v", tt.path, got, want)
+			}
+		})
+	}
+}
+
+func TestParseConfValueInvalid(t *testing.T) {
+	schema := loadTestSchema(t, fieldTypesSchema)
+	tests := []struct {
+		path string
+		raw  string
+	}{
+		{"audio.features", "many"},
+		{"audio.tint", "teal"},
+		{"audio.names", "no pairs"},
+	}
+	for _, tt := range tests {
+		field, _ := LookupField(schema, tt.path)
+		// 无法解析的文本原样保留，交给校验报告
+		if got := parseConfValue(tt.raw, field); got != tt.raw {
+			t.Errorf("parseConfValue(%s, %q) = %#v, want the raw text", tt.path, tt.raw, got)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"configcraft/internal/models"
+)
+
+// IsOverride 值是否覆盖了字段的默认值；没有值时使用默认值，不算覆盖
+// YAML中数字和字符串形式的相同值（如选项值0和"0"）视为相同
+func IsOverride(field models.ConfigField, value interface{}) bool {
+	if value == nil {
+		return false
+	}
+	if field.Default == nil {
+		return true
+	}
+	return fmt.Sprintf("%v", value) != fmt.Sprintf("%v", field.Default)
+}
+
+// OverrideCounts 统计每个分组（section）和子分组（section.group）中覆盖了默认值的字段数
+// 分组的数量包含其下所有子分组
+func OverrideCounts(schema *models.Schema, config *models.UserConfig) map[string]int {
+	counts := make(map[string]int)
+	if config == nil {
+		return counts
+	}
+
+	ForEachField(schema, func(path string, field models.ConfigField) {
+		if !IsOverride(field, config.Values[path]) {
+			return
+		}
+
+		parts := strings.SplitN(path, ".", 3)
+		counts[parts[0]]++
+		if len(parts) == 3 {
+			if _, isGroup := schema.Sections[parts[0]].Groups[parts[1]]; isGroup {
+				counts[parts[0]+"."+parts[1]]++
+			}
+		}
+	})
+	return counts
+}
+
+// MaterializeDefaults 把schema中有默认值但配置中没有值的字段写入配置，返回新写入的字段路径
+// 使配置内容不依赖于界面上打开过哪些分组
+func MaterializeDefaults(schema *models.Schema, config *models.UserConfig) []string {
+	if config.Values == nil {
+		config.Values = make(map[string]interface{})
+	}
+
+	var added []string
+	ForEachField(schema, func(path string, field models.ConfigField) {
+		if _, exists := config.Values[path]; exists || field.Default == nil {
+			return
+		}
+		config.Values[path] = CopyValue(field.Default)
+		added = append(added, path)
+	})
+	return added
+}
+
+// CopyValue 深拷贝配置值中的map和列表
+// 写入配置的默认值必须是副本，否则之后修改配置（如编辑map字段）会同时改掉schema中的默认值
+func CopyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			copied[key] = CopyValue(item)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = CopyValue(item)
+		}
+		return copied
+	case []string:
+		return append([]string(nil), v...)
+	}
+	return value
+}
+
+// 输出模式：决定生成的文件中包含哪些配置项
+const (
+	OutputFull         = "full"      // 配置中的所有值（默认）
+	OutputWithDefaults = "defaults"  // 配置中的所有值，加上schema中没有值的字段的默认值
+	OutputOverrides    = "overrides" // 只输出与schema默认值不同的值
+)
+
+// OutputModes 支持的输出模式
+var OutputModes = []string{OutputFull, OutputWithDefaults, OutputOverrides}
+
+// OutputValues 按输出模式选出要写入输出文件的配置项，不修改config
+// schema为空时各模式都等同于OutputFull
+func OutputValues(schema *models.Schema, config *models.UserConfig, mode string) (map[string]interface{}, error) {
+	values := make(map[string]interface{}, len(config.Values))
+
+	switch mode {
+	case "", OutputFull:
+		for path, value := range config.Values {
+			values[path] = value
+		}
+	case OutputWithDefaults:
+		for path, value := range config.Values {
+			values[path] = value
+		}
+		ForEachField(schema, func(path string, field models.ConfigField) {
+			if _, exists := values[path]; !exists && field.Default != nil {
+				values[path] = field.Default
+			}
+		})
+	case OutputOverrides:
+		for path, value := range config.Values {
+			// schema中没有的配置项没有默认值，总是输出
+			field, _ := LookupField(schema, path)
+			if IsOverride(field, value) {
+				values[path] = value
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported output mode: %s", mode)
+	}
+
+	return values, nil
+}
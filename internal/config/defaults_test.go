@@ -0,0 +1,153 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"configcraft/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// loadTestSchema 解析schema文本
+func loadTestSchema(t *testing.T, content string) *models.Schema {
+	t.Helper()
+	var schema models.Schema
+	if err := yaml.Unmarshal([]byte(content), &schema); err != nil {
+		t.Fatal(err)
+	}
+	return &schema
+}
+
+func TestOutputValues(t *testing.T) {
+	schema := loadTestSchema(t, testSchema)
+	config := &models.UserConfig{Values: map[string]interface{}{
+		"basic.level": 5,   // 等于默认值
+		"basic.name":  "x", // 没有默认值
+		"extra.flag":  true,
+	}}
+
+	tests := []struct {
+		mode string
+		want map[string]interface{}
+	}{
+		{"", map[string]interface{}{"basic.level": 5, "basic.name": "x", "extra.flag": true}},
+		{OutputFull, map[string]interface{}{"basic.level": 5, "basic.name": "x", "extra.flag": true}},
+		{OutputWithDefaults, map[string]interface{}{"basic.level": 5, "basic.name": "x", "extra.flag": true}},
+		{OutputOverrides, map[string]interface{}{"basic.name": "x", "extra.flag": true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			got, err := OutputValues(schema, config, tt.mode)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("OutputValues(%q) = %v, want %v", tt.mode, got, tt.want)
+			}
+		})
+	}
+
+	// 配置中没有值时，defaults模式补上默认值，其他模式不输出
+	empty := &models.UserConfig{Values: map[string]interface{}{}}
+	for mode, want := range map[string]int{OutputFull: 0, OutputWithDefaults: 1, OutputOverrides: 0} {
+		if got, _ := OutputValues(schema, empty, mode); len(got) != want {
+			t.Errorf("OutputValues(%q) of an empty config = %v", mode, got)
+		}
+	}
+
+	if _, err := OutputValues(schema, config, "minimal"); err == nil {
+		t.Error("unsupported mode must fail")
+	}
+}
+
+func TestSaveConfigWithConfMode(t *testing.T) {
+	tests := []struct {
+		mode    string
+		present []string
+		absent  []string
+	}{
+		{OutputFull, []string{"_BASIC_LEVEL=5", "_BASIC_NAME=x"}, nil},
+		{OutputOverrides, []string{"_BASIC_NAME=x"}, []string{"_BASIC_LEVEL"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			dir := t.TempDir()
+			p := NewParser()
+			if err := p.LoadSchema(writeSchema(t, dir, testSchema)); err != nil {
+				t.Fatal(err)
+			}
+			yamlPath := filepath.Join(dir, "cfg.yaml")
+			config := &models.UserConfig{Values: map[string]interface{}{"basic.level": 5, "basic.name": "x"}}
+
+			// 第二次保存时按同一模式检查手工修改，自身生成的conf不算修改
+			for i := 0; i < 2; i++ {
+				if err := p.SaveConfigWithConf(config, yamlPath, tt.mode); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			conf := readFile(t, ConfPathFor(yamlPath))
+			for _, want := range tt.present {
+				if !strings.Contains(conf, want) {
+					t.Errorf("conf does not contain %s:\n%s", want, conf)
+				}
+			}
+			for _, unwanted := range tt.absent {
+				if strings.Contains(conf, unwanted) {
+					t.Errorf("conf contains %s:\n%s", unwanted, conf)
+				}
+			}
+		})
+	}
+}
+
+func TestMaterializeDefaultsCopiesValues(t *testing.T) {
+	schema := loadTestSchema(t, `sections:
+  basic:
+    fields:
+      level:
+        type: number
+        default: 5
+      keys:
+        type: map
+        default: {a: 1, b: {nested: 2}}
+      modes:
+        type: flags
+        default: [x, y]
+`)
+
+	tests := []struct {
+		name   string
+		values map[string]interface{}
+		added  []string
+	}{
+		{"fills missing", map[string]interface{}{}, []string{"basic.keys", "basic.level", "basic.modes"}},
+		{"keeps existing", map[string]interface{}{"basic.level": 7}, []string{"basic.keys", "basic.modes"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &models.UserConfig{Values: tt.values}
+			added := MaterializeDefaults(schema, config)
+			sort.Strings(added)
+			if !reflect.DeepEqual(added, tt.added) {
+				t.Fatalf("added = %v, want %v", added, tt.added)
+			}
+
+			// 修改配置中的值不能改变schema的默认值
+			config.Values["basic.keys"].(map[string]interface{})["a"] = 100
+			config.Values["basic.keys"].(map[string]interface{})["b"].(map[string]interface{})["nested"] = 200
+			config.Values["basic.modes"].([]interface{})[0] = "z"
+
+			fields := schema.Sections["basic"].Fields
+			if want := map[string]interface{}{"a": 1, "b": map[string]interface{}{"nested": 2}}; !reflect.DeepEqual(fields["keys"].Default, want) {
+				t.Errorf("map default changed to %v", fields["keys"].Default)
+			}
+			if want := []interface{}{"x", "y"}; !reflect.DeepEqual(fields["modes"].Default, want) {
+				t.Errorf("list default changed to %v", fields["modes"].Default)
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package config
+
+import (
+	"fmt"
+	"image/color"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"configcraft/internal/models"
+)
+
+// flags字段的输出格式
+const (
+	FlagsList = "list" // 选中的选项值以逗号分隔（默认）
+	FlagsMask = "mask" // 选中的选项值按位或，输出为十六进制
+)
+
+// color字段的输出格式
+const (
+	ColorHTML = "html" // #RRGGBB（默认）
+	ColorHex  = "hex"  // 0xRRGGBB
+)
+
+// FlagList flags字段的值（YAML中的列表）中选中的选项值，值不是列表时返回false
+func FlagList(value interface{}) ([]interface{}, bool) {
+	switch v := value.(type) {
+	case []interface{}:
+		return v, true
+	case []string:
+		list := make([]interface{}, len(v))
+		for i, item := range v {
+			list[i] = item
+		}
+		return list, true
+	}
+	return nil, false
+}
+
+// flagsMask 选中的选项值按位或，选项值必须是整数
+func flagsMask(values []interface{}) (int64, error) {
+	var mask int64
+	for _, value := range values {
+		bits, err := strconv.ParseInt(fmt.Sprintf("%v", value), 0, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%v is not an integer bit value", value)
+		}
+		mask |= bits
+	}
+	return mask, nil
+}
+
+// ParseColor 解析color字段的值#RRGGBB
+func ParseColor(value interface{}) (color.NRGBA, bool) {
+	text, ok := value.(string)
+	if !ok || len(text) != 7 || text[0] != '#' {
+		return color.NRGBA{}, false
+	}
+	rgb, err := strconv.ParseUint(text[1:], 16, 32)
+	if err != nil {
+		return color.NRGBA{}, false
+	}
+	return color.NRGBA{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb), A: 0xff}, true
+}
+
+// ColorText 颜色的#RRGGBB写法
+func ColorText(c color.Color) string {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return fmt.Sprintf("#%02X%02X%02X", n.R, n.G, n.B)
+}
+
+// MapValue map字段的值（YAML中的映射），值不是映射时返回false
+func MapValue(value interface{}) (map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case map[string]string:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[key] = item
+		}
+		return m, true
+	}
+	return nil, false
+}
+
+// hasExtension 路径是否以其中一个扩展名结尾（不区分大小写）
+func hasExtension(path string, extensions []string) bool {
+	ext := filepath.Ext(path)
+	for _, allowed := range extensions {
+		if strings.EqualFold(ext, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatFieldValue 按字段类型把配置值格式化为输出文件中的文本
+// flags字段的mask格式输出按位或的十六进制值，color字段的hex格式输出0xRRGGBB，其他与FormatValue相同
+func FormatFieldValue(field models.ConfigField, value interface{}) string {
+	switch field.Type {
+	case "flags":
+		if list, ok := FlagList(value); ok && field.Format == FlagsMask {
+			if mask, err := flagsMask(list); err == nil {
+				return fmt.Sprintf("0x%X", mask)
+			}
+		}
+	case "color":
+		if c, ok := ParseColor(value); ok {
+			text := ColorText(c)
+			if field.Format == ColorHex {
+				return "0x" + text[1:]
+			}
+			return text
+		}
+	}
+	return FormatValue(value)
+}
+
+// parseConfFlags 把conf中flags字段的文本还原为选项值列表：mask格式按位拆分，list格式按逗号拆分
+func parseConfFlags(raw string, field models.ConfigField) interface{} {
+	list := []interface{}{}
+	if field.Format == FlagsMask {
+		mask, err := strconv.ParseInt(raw, 0, 64)
+		if err != nil {
+			return raw
+		}
+		for _, option := range field.Options {
+			bits, err := strconv.ParseInt(fmt.Sprintf("%v", option.Value), 0, 64)
+			if err == nil && bits != 0 && mask&bits == bits {
+				list = append(list, option.Value)
+			}
+		}
+		return list
+	}
+
+	if raw == "" {
+		return list
+	}
+	for _, item := range strings.Split(raw, ",") {
+		list = append(list, parseConfValue(strings.TrimSpace(item), models.ConfigField{Options: field.Options}))
+	}
+	return list
+}
+
+// parseConfColor 把conf中的#RRGGBB或0xRRGGBB还原为#RRGGBB
+func parseConfColor(raw string) interface{} {
+	text := raw
+	if strings.HasPrefix(strings.ToLower(raw), "0x") {
+		text = "#" + raw[2:]
+	}
+	if c, ok := ParseColor(text); ok {
+		return ColorText(c)
+	}
+	return raw
+}
+
+// parseConfMap 把conf中的key=value,key=value还原为映射，值都作为字符串
+func parseConfMap(raw string) interface{} {
+	m := make(map[string]interface{})
+	if raw == "" {
+		return m
+	}
+	for _, pair := range strings.Split(raw, ",") {
+		key, value, found := strings.Cut(pair, "=")
+		if !found {
+			return raw
+		}
+		m[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return m
+}
+
+// validateFieldValue 检查flags、color、path和map字段的值，返回发现的问题
+func validateFieldValue(field models.ConfigField, value interface{}, add func(severity, format string, args ...interface{})) {
+	switch field.Type {
+	case "flags":
+		list, ok := FlagList(value)
+		if !ok {
+			add(SeverityError, "expected a list of options, got %v", value)
+			return
+		}
+		seen := make(map[string]bool)
+		for _, item := range list {
+			text := fmt.Sprintf("%v", item)
+			if !hasOption(field, item) {
+				add(SeverityError, "%v is not one of the allowed options", item)
+			} else if seen[text] {
+				add(SeverityWarning, "%v is selected more than once", item)
+			}
+			seen[text] = true
+		}
+	case "color":
+		if _, ok := ParseColor(value); !ok {
+			add(SeverityError, "expected a color like #RRGGBB, got %v", value)
+		}
+	case "path":
+		path, ok := value.(string)
+		if !ok {
+			add(SeverityError, "expected a file path, got %v", value)
+			return
+		}
+		if path != "" && len(field.Extensions) > 0 && !hasExtension(path, field.Extensions) {
+			add(SeverityWarning, "%s does not have one of the extensions %s", path, strings.Join(field.Extensions, ", "))
+		}
+	case "map":
+		m, ok := MapValue(value)
+		if !ok {
+			add(SeverityError, "expected a mapping of keys to values, got %v", value)
+			return
+		}
+		keys := make([]string, 0, len(m))
+		for key := range m {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			// 输出为key=value,key=value，键和值中不能出现分隔符
+			if key == "" || strings.ContainsAny(key, ",=") {
+				add(SeverityError, "key %q must not be empty or contain ',' or '='", key)
+			}
+			switch item := m[key].(type) {
+			case map[string]interface{}, []interface{}:
+				add(SeverityError, "value of %q must be a single value, got %v", key, item)
+			default:
+				if strings.Contains(FormatValue(item), ",") {
+					add(SeverityError, "value of %q must not contain ','", key)
+				}
+			}
+		}
+	}
+}
+
+// checkFieldTypes 检查schema中字段类型相关的设置：flags需要选项，mask格式的选项值是整数位，
+// format只用于flags和color，extensions只用于path，color的默认值是#RRGGBB
+func checkFieldTypes(schema *models.Schema) error {
+	var err error
+	ForEachField(schema, func(path string, field models.ConfigField) {
+		if err == nil {
+			if e := checkFieldType(field); e != nil {
+				err = fmt.Errorf("field %s: %w", path, e)
+			}
+		}
+	})
+	return err
+}
+
+func checkFieldType(field models.ConfigField) error {
+	if len(field.Extensions) > 0 && field.Type != "path" {
+		return fmt.Errorf("extensions are only supported by path fields")
+	}
+
+	switch field.Type {
+	case "flags":
+		if len(field.Options) == 0 {
+			return fmt.Errorf("flags field requires options")
+		}
+		switch field.Format {
+		case "", FlagsList:
+		case FlagsMask:
+			for _, option := range field.Options {
+				if mask, err := flagsMask([]interface{}{option.Value}); err != nil || mask == 0 {
+					return fmt.Errorf("option %v is not a non-zero integer bit value required by the mask format", option.Value)
+				}
+			}
+		default:
+			return fmt.Errorf("invalid flags format %q, expected %s or %s", field.Format, FlagsList, FlagsMask)
+		}
+	case "color":
+		switch field.Format {
+		case "", ColorHTML, ColorHex:
+		default:
+			return fmt.Errorf("invalid color format %q, expected %s or %s", field.Format, ColorHTML, ColorHex)
+		}
+		if _, ok := ParseColor(field.Default); field.Default != nil && !ok {
+			return fmt.Errorf("default %v is not a color like #RRGGBB", field.Default)
+		}
+	default:
+		if field.Format != "" {
+			return fmt.Errorf("format is only supported by flags and color fields")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package config
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"configcraft/internal/models"
+)
+
+// builtinConfTemplate 内置的conf生成模板
+//
+//go:embed templates/conf.tmpl
+var builtinConfTemplate string
+
+// TemplateData 传给生成模板的数据
+type TemplateData struct {
+	Schema      *models.Schema              // 当前schema，可能为nil
+	Values      map[string]interface{}      // 按输出模式选出的配置项
+	Notes       map[string]models.FieldNote // 配置项的备注，按路径
+	Mode        string                      // 输出模式
+	GeneratedAt time.Time
+	Header      HeaderData // 文件头信息，通常通过header函数输出
+}
+
+// TemplateSection 模板中按section分组的配置项
+type TemplateSection struct {
+	Key     string // section键名，一级配置为"general"
+	Name    string // 显示名称
+	Entries []TemplateEntry
+}
+
+// TemplateEntry 模板中的一个配置项
+type TemplateEntry struct {
+	Path  string             // YAML中的完整路径
+	Key   string             // 按命名规则生成的键名
+	Value interface{}        // 配置值
+	Field models.ConfigField // schema中的字段定义，schema中没有该字段时为空
+	Note  models.FieldNote   // 配置项的备注，没有备注时为空
+}
+
+// templateFor 返回格式对应的模板路径；为空表示使用内置的conf模板
+// schema的templates可以定义新格式，也可以覆盖内置的conf格式
+func (p *Parser) templateFor(format string) (string, error) {
+	if p.schema != nil {
+		if path, exists := p.schema.Templates[format]; exists {
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(p.schemaDir, filepath.FromSlash(path))
+			}
+			return path, nil
+		}
+	}
+	if format == "" || format == FormatConf {
+		return "", nil
+	}
+	return "", fmt.Errorf("unsupported output format %q", format)
+}
+
+// renderTemplate 用模板文件生成outputPath的内容，templatePath为空时使用内置的conf模板
+// 模板输出了文件头中的校验和时，在生成完毕后填入实际的值
+func (p *Parser) renderTemplate(templatePath string, config *models.UserConfig, mode, outputPath string) ([]byte, error) {
+	text := builtinConfTemplate
+	name := FormatConf
+	if templatePath != "" {
+		data, err := os.ReadFile(templatePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template: %w", err)
+		}
+		text = string(data)
+		name = filepath.Base(templatePath)
+	}
+
+	values, err := OutputValues(p.schema, config, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	data := TemplateData{
+		Schema:      p.schema,
+		Values:      values,
+		Notes:       config.Notes,
+		Mode:        mode,
+		GeneratedAt: now,
+		Header:      p.headerData(config, outputPath, now),
+	}
+
+	tmpl, err := template.New(name).Funcs(p.templateFuncs(data)).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template %s: %w", name, err)
+	}
+	return sealChecksum([]byte(out.String())), nil
+}
+
+// templateFuncs 生成模板可用的辅助函数
+func (p *Parser) templateFuncs(data TemplateData) template.FuncMap {
+	return template.FuncMap{
+		// 文件头：按注释前缀输出header模板，如 {{header "#"}}、{{header "//"}}
+		"header": func(prefix string) (string, error) { return p.renderHeader(data.Header, prefix) },
+
+		// 键名和字段
+		"outputKey": func(path string) string { return OutputKey(p.schema, path) },
+		"field": func(path string) models.ConfigField {
+			field, _ := LookupField(p.schema, path)
+			return field
+		},
+		"sections":    func() []TemplateSection { return p.templateSections(data.Values, data.Notes) },
+		"sectionName": p.getSectionName,
+
+		// 值的格式化
+		"formatValue": FormatValue,
+		"formatField": FormatFieldValue,
+		"quote":       func(value interface{}) string { return strconv.Quote(FormatValue(value)) },
+
+		// 备注：按注释前缀输出配置项的备注，如 {{note "#" .Path}}，没有备注时为空
+		"note": func(prefix, path string) string { return NoteComment(prefix, data.Notes[path]) },
+
+		// 字符串处理
+		"upper":   strings.ToUpper,
+		"lower":   strings.ToLower,
+		"replace": strings.ReplaceAll,
+		"repeat":  strings.Repeat,
+		"join":    strings.Join,
+		"add":     func(a, b int) int { return a + b },
+	}
+}
+
+// templateSections 把配置项按section分组，顺序与conf文件相同：一级配置在前，其余按section和键名排序
+func (p *Parser) templateSections(values map[string]interface{}, notes map[string]models.FieldNote) []TemplateSection {
+	paths := sortedKeys(values)
+	sort.SliceStable(paths, func(i, j int) bool {
+		return !strings.Contains(paths[i], ".") && strings.Contains(paths[j], ".")
+	})
+
+	var sections []TemplateSection
+	for _, path := range paths {
+		sectionKey := "general" // 一级配置归入通用分组
+		if parts := strings.Split(path, "."); len(parts) >= 2 {
+			sectionKey = strings.ToLower(parts[0])
+		}
+		if len(sections) == 0 || sections[len(sections)-1].Key != sectionKey {
+			sections = append(sections, TemplateSection{Key: sectionKey, Name: p.getSectionName(sectionKey)})
+		}
+
+		field, _ := LookupField(p.schema, path)
+		current := &sections[len(sections)-1]
+		current.Entries = append(current.Entries, TemplateEntry{
+			Path:  path,
+			Key:   OutputKey(p.schema, path),
+			Value: values[path],
+			Field: field,
+			Note:  notes[path],
+		})
+	}
+	return sections
+}
+
+// FormatValue 把配置值格式化为输出文件中的文本
+// 列表（flags）输出为逗号分隔的各项，映射（map）按键排序输出为key=value,key=value
+func FormatValue(value interface{}) string {
+	if list, ok := FlagList(value); ok {
+		items := make([]string, len(list))
+		for i, item := range list {
+			items[i] = FormatValue(item)
+		}
+		return strings.Join(items, ",")
+	}
+	if m, ok := MapValue(value); ok {
+		pairs := make([]string, 0, len(m))
+		for _, key := range sortedKeys(m) {
+			pairs = append(pairs, key+"="+FormatValue(m[key]))
+		}
+		return strings.Join(pairs, ",")
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"bytes"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"configcraft/internal/models"
+	"configcraft/internal/version"
+)
+
+// builtinHeaderTemplate 默认的生成文件头模板
+//
+//go:embed templates/header.tmpl
+var builtinHeaderTemplate string
+
+// checksumPlaceholder 生成时先占位，内容生成完毕后替换为实际的校验和
+var checksumPlaceholder = "sha256:" + strings.Repeat("0", sha256.Size*2)
+
+// checksumPattern 匹配文件中的校验和，第一个匹配所在的行不参与计算
+var checksumPattern = regexp.MustCompile(`sha256:([0-9a-f]{64})`)
+
+// ErrNoChecksum 文件中没有校验和（不是生成的文件，或模板中没有输出校验和）
+var ErrNoChecksum = errors.New("file does not contain a checksum")
+
+// HeaderData 文件头模板可用的数据
+type HeaderData struct {
+	Product          string // 产品名称，schema的product，未设置时为display_name
+	SchemaVersion    string
+	FileName         string // 生成的文件名
+	ConfigFile       string // 来源YAML配置的文件名，未保存的配置为空
+	GitRevision      string // 输出目录所在git仓库的当前提交，不在仓库中时为空
+	User             string
+	GeneratorVersion string
+	GeneratedAt      time.Time
+	Checksum         string // 内容校验和，必须输出在单独的一行中
+}
+
+// headerData 收集生成outputPath时文件头中的信息
+func (p *Parser) headerData(config *models.UserConfig, outputPath string, generatedAt time.Time) HeaderData {
+	data := HeaderData{
+		FileName:         filepath.Base(outputPath),
+		GitRevision:      gitRevision(filepath.Dir(outputPath)),
+		User:             currentUser(),
+		GeneratorVersion: version.Version,
+		GeneratedAt:      generatedAt,
+		Checksum:         checksumPlaceholder,
+	}
+	if config.FilePath != "" {
+		data.ConfigFile = filepath.Base(config.FilePath)
+	}
+	if p.schema != nil {
+		data.Product = p.schema.Product
+		if data.Product == "" {
+			data.Product = p.schema.DisplayName.String()
+		}
+		data.SchemaVersion = p.schema.SchemaVersion
+	}
+	return data
+}
+
+// renderHeader 用schema中的header模板（未设置时用默认模板）生成文件头，每行加上注释前缀
+func (p *Parser) renderHeader(data HeaderData, prefix string) (string, error) {
+	text := builtinHeaderTemplate
+	if p.schema != nil && p.schema.Header != "" {
+		text = p.schema.Header
+	}
+
+	tmpl, err := template.New("header").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse header template: %w", err)
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("failed to execute header template: %w", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = prefix
+		} else {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// sealChecksum 计算内容的校验和并替换占位符；没有占位符时原样返回
+func sealChecksum(data []byte) []byte {
+	index := bytes.Index(data, []byte(checksumPlaceholder))
+	if index < 0 {
+		return data
+	}
+	sum := contentChecksum(data, index)
+	return bytes.Replace(data, []byte(checksumPlaceholder), []byte("sha256:"+sum), 1)
+}
+
+// VerifyChecksum 检查生成的文件内容是否与其中记录的校验和一致
+// 返回false表示文件在生成后被修改过；没有校验和时返回ErrNoChecksum
+func VerifyChecksum(data []byte) (bool, error) {
+	match := checksumPattern.FindSubmatchIndex(data)
+	if match == nil {
+		return false, ErrNoChecksum
+	}
+	return contentChecksum(data, match[0]) == string(data[match[2]:match[3]]), nil
+}
+
+// VerifyFile 读取文件并检查校验和
+func VerifyFile(filePath string) (bool, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return false, err
+	}
+	return VerifyChecksum(data)
+}
+
+// contentChecksum 计算去掉校验和所在行（offset为该行中的任意位置）后内容的SHA-256
+func contentChecksum(data []byte, offset int) string {
+	start := bytes.LastIndexByte(data[:offset], '\n') + 1
+	end := len(data)
+	if next := bytes.IndexByte(data[offset:], '\n'); next >= 0 {
+		end = offset + next + 1
+	}
+
+	hash := sha256.New()
+	hash.Write(data[:start])
+	hash.Write(data[end:])
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// gitRevision 返回目录所在git仓库的当前版本（git describe），有未提交的修改时加上"-dirty"
+func gitRevision(dir string) string {
+	output, err := exec.Command("git", "-C", dir, "describe", "--always", "--dirty").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// currentUser 当前用户名，获取失败时使用环境变量
+func currentUser() string {
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		return current.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return os.Getenv("USERNAME")
+}
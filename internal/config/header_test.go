@@ -0,0 +1,66 @@
+package config
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"configcraft/internal/models"
+)
+
+// sealedFile 带校验和占位符的生成内容
+var sealedFile = "# @file test.conf\n# @checksum  " + checksumPlaceholder + "\n_BASIC_VOLUME=8\n"
+
+func TestChecksumSealAndVerify(t *testing.T) {
+	sealed := string(sealChecksum([]byte(sealedFile)))
+	if strings.Contains(sealed, checksumPlaceholder) {
+		t.Fatal("placeholder was not replaced")
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		ok      bool
+		err     error
+	}{
+		{"sealed", sealed, true, nil},
+		{"edited value", strings.Replace(sealed, "=8", "=9", 1), false, nil},
+		{"added line", sealed + "_BASIC_MODE=1\n", false, nil},
+		{"edited checksum line only", strings.Replace(sealed, "@checksum  ", "@checksum ", 1), true, nil},
+		{"no checksum", "_BASIC_VOLUME=8\n", false, ErrNoChecksum},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := VerifyChecksum([]byte(tt.content))
+			if ok != tt.ok || !errors.Is(err, tt.err) {
+				t.Errorf("VerifyChecksum = %v, %v; want %v, %v", ok, err, tt.ok, tt.err)
+			}
+		})
+	}
+
+	if got := string(sealChecksum([]byte("no placeholder\n"))); got != "no placeholder\n" {
+		t.Errorf("content without a placeholder changed to %q", got)
+	}
+}
+
+func TestGeneratedFileIsSealed(t *testing.T) {
+	dir := t.TempDir()
+	p := NewParser()
+	if err := p.LoadSchema(writeSchema(t, dir, testSchema)); err != nil {
+		t.Fatal(err)
+	}
+	confPath := filepath.Join(dir, "cfg.conf")
+	config := &models.UserConfig{Values: map[string]interface{}{"basic.level": 3}}
+	if err := p.GenerateConfFile(config, confPath, OutputFull); err != nil {
+		t.Fatal(err)
+	}
+
+	if ok, err := VerifyFile(confPath); !ok || err != nil {
+		t.Fatalf("generated file does not verify: %v, %v", ok, err)
+	}
+	writeFile(t, confPath, strings.Replace(readFile(t, confPath), "=3", "=4", 1))
+	if ok, err := VerifyFile(confPath); ok || err != nil {
+		t.Errorf("edited file verifies: %v, %v", ok, err)
+	}
+}
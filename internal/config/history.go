@@ -0,0 +1,161 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"configcraft/internal/models"
+	"configcraft/internal/version"
+	"gopkg.in/yaml.v3"
+)
+
+// historySuffix 变更记录文件名的后缀，与YAML配置放在同一目录
+const historySuffix = ".history.yaml"
+
+// ChangeEntry 变更记录中的一条：一次保存中一个配置项的值从Old变为New
+type ChangeEntry struct {
+	Path    string      `yaml:"path"`
+	Old     interface{} `yaml:"old,omitempty"` // 新增的配置项为空
+	New     interface{} `yaml:"new,omitempty"` // 删除的配置项为空
+	User    string      `yaml:"user"`
+	Time    time.Time   `yaml:"time"`
+	Version string      `yaml:"version"` // 保存时的ConfigCraft版本
+}
+
+// Added 是否为新增配置项
+func (e ChangeEntry) Added() bool {
+	return e.Old == nil
+}
+
+// Removed 是否为删除配置项
+func (e ChangeEntry) Removed() bool {
+	return e.New == nil
+}
+
+// HistoryPathFor 返回YAML配置对应的变更记录文件路径（同目录，如dhf_config.history.yaml）
+func HistoryPathFor(yamlPath string) string {
+	dir := filepath.Dir(yamlPath)
+	base := strings.TrimSuffix(filepath.Base(yamlPath), filepath.Ext(yamlPath))
+	return filepath.Join(dir, base+historySuffix)
+}
+
+// isHistoryFile 是否为变更记录文件；变更记录只追加，保存时不轮转备份
+func isHistoryFile(path string) bool {
+	return strings.HasSuffix(path, historySuffix)
+}
+
+// diffChanges 比较两个版本的配置值，按路径排序返回变化的项
+func diffChanges(previous, current map[string]interface{}, at time.Time) []ChangeEntry {
+	paths := make(map[string]bool)
+	for path := range previous {
+		paths[path] = true
+	}
+	for path := range current {
+		paths[path] = true
+	}
+
+	user := currentUser()
+	var entries []ChangeEntry
+	for _, path := range sortedKeys(paths) {
+		oldValue, newValue := previous[path], current[path]
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		entries = append(entries, ChangeEntry{
+			Path:    path,
+			Old:     oldValue,
+			New:     newValue,
+			User:    user,
+			Time:    at,
+			Version: version.Version,
+		})
+	}
+	return entries
+}
+
+// addChangeLog 把本次保存相对磁盘上的版本所做的修改追加到变更记录，与其他文件一起提交
+// YAML还不存在时所有值都记为新增；磁盘上的YAML无法解析时同样按新增记录，不阻止保存
+func (p *Parser) addChangeLog(files map[string][]byte, values map[string]interface{}, yamlPath string) error {
+	previous := make(map[string]interface{})
+	if _, err := os.Stat(yamlPath); err == nil {
+		if config, err := p.LoadUserConfig(yamlPath); err == nil {
+			previous = config.Values
+		}
+	}
+
+	entries := diffChanges(previous, values, time.Now().Truncate(time.Second))
+	if len(entries) == 0 {
+		return nil
+	}
+	data, err := appendHistory(HistoryPathFor(yamlPath), entries)
+	if err != nil {
+		return err
+	}
+	files[HistoryPathFor(yamlPath)] = data
+	return nil
+}
+
+// appendHistory 返回在变更记录文件末尾追加entries后的内容
+// 文件是一个YAML列表，新的项直接接在原内容之后，不改动已有的记录
+func appendHistory(historyPath string, entries []ChangeEntry) ([]byte, error) {
+	data, err := os.ReadFile(historyPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read change history: %w", err)
+	}
+	if len(data) > 0 && !bytes.HasSuffix(data, []byte("\n")) {
+		data = append(data, '\n')
+	}
+
+	added, err := yaml.Marshal(entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal change history: %w", err)
+	}
+	return append(data, added...), nil
+}
+
+// ReadHistory 读取YAML配置的变更记录，最近的在前；没有变更记录时返回nil
+func ReadHistory(yamlPath string) ([]ChangeEntry, error) {
+	data, err := os.ReadFile(HistoryPathFor(yamlPath))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read change history: %w", err)
+	}
+
+	var entries []ChangeEntry
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse change history: %w", err)
+	}
+	// 文件按保存顺序追加：以一次保存（时间相同的连续记录）为单位倒序，同一次保存内保持按路径排列
+	var saves [][]ChangeEntry
+	for i, entry := range entries {
+		if i == 0 || !entry.Time.Equal(entries[i-1].Time) {
+			saves = append(saves, nil)
+		}
+		saves[len(saves)-1] = append(saves[len(saves)-1], entry)
+	}
+	newest := make([]ChangeEntry, 0, len(entries))
+	for i := len(saves) - 1; i >= 0; i-- {
+		newest = append(newest, saves[i]...)
+	}
+	return newest, nil
+}
+
+// RevertChange 把配置项恢复为变更前的值，新增的项被移除
+func RevertChange(config *models.UserConfig, entry ChangeEntry) {
+	if entry.Added() {
+		delete(config.Values, entry.Path)
+		return
+	}
+	if config.Values == nil {
+		config.Values = make(map[string]interface{})
+	}
+	config.Values[entry.Path] = entry.Old
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"configcraft/internal/models"
+)
+
+func TestDiffChanges(t *testing.T) {
+	at := time.Date(2025, 9, 1, 10, 0, 0, 0, time.UTC)
+	previous := map[string]interface{}{"a": 1, "b": "x", "c": []interface{}{"SBC"}}
+	current := map[string]interface{}{"a": 2, "c": []interface{}{"SBC"}, "d": true}
+
+	entries := diffChanges(previous, current, at)
+	tests := []struct {
+		path           string
+		old, new       interface{}
+		added, removed bool
+	}{
+		{"a", 1, 2, false, false},
+		{"b", "x", nil, false, true},
+		{"d", nil, true, true, false},
+	}
+	if len(entries) != len(tests) {
+		t.Fatalf("got %d entries, want %d: %+v", len(entries), len(tests), entries)
+	}
+	for i, tt := range tests {
+		entry := entries[i]
+		if entry.Path != tt.path || !reflect.DeepEqual(entry.Old, tt.old) || !reflect.DeepEqual(entry.New, tt.new) {
+			t.Errorf("entry %d = %+v, want %s: %v -> %v", i, entry, tt.path, tt.old, tt.new)
+		}
+		if entry.Added() != tt.added || entry.Removed() != tt.removed {
+			t.Errorf("%s added/removed = %v/%v", entry.Path, entry.Added(), entry.Removed())
+		}
+		if !entry.Time.Equal(at) || entry.Version == "" {
+			t.Errorf("%s time/version = %v/%q", entry.Path, entry.Time, entry.Version)
+		}
+	}
+}
+
+func TestHistoryAppend(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "cfg.yaml")
+	historyPath := HistoryPathFor(yamlPath)
+	if historyPath != filepath.Join(dir, "cfg.history.yaml") {
+		t.Fatalf("HistoryPathFor = %s", historyPath)
+	}
+
+	first := time.Date(2025, 9, 1, 10, 0, 0, 0, time.UTC)
+	second := first.Add(time.Minute)
+	saves := [][]ChangeEntry{
+		diffChanges(nil, map[string]interface{}{"a": 1, "b": 1}, first),
+		diffChanges(map[string]interface{}{"a": 1, "b": 1}, map[string]interface{}{"a": 2, "b": 3}, second),
+	}
+	var previous string
+	for _, entries := range saves {
+		data, err := appendHistory(historyPath, entries)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// 追加不改动已有的记录
+		if !strings.HasPrefix(string(data), previous) {
+			t.Fatalf("existing history was rewritten:\n%s", data)
+		}
+		previous = string(data)
+		writeFile(t, historyPath, previous)
+	}
+
+	entries, err := ReadHistory(yamlPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, entry := range entries {
+		got = append(got, entry.Path+"@"+entry.Time.Format("15:04"))
+	}
+	// 最近一次保存在前，同一次保存内按路径排列
+	if want := []string{"a@10:01", "b@10:01", "a@10:00", "b@10:00"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("history = %v, want %v", got, want)
+	}
+
+	if entries, err := ReadHistory(filepath.Join(dir, "other.yaml")); entries != nil || err != nil {
+		t.Errorf("missing history = %v, %v", entries, err)
+	}
+}
+
+func TestSaveAppendsHistory(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "cfg.yaml")
+	writeFile(t, yamlPath, "values:\n    basic.level: 1\n")
+
+	p := NewParser()
+	p.SetBackupCount(3)
+	for _, level := range []int{2, 2, 3} {
+		config := &models.UserConfig{Values: map[string]interface{}{"basic.level": level}}
+		if err := p.OverwriteConfigWithConf(config, yamlPath, OutputFull); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := ReadHistory(yamlPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 值没有变化的保存不产生记录
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2: %+v", len(entries), entries)
+	}
+	for _, backup := range p.ListBackups(yamlPath) {
+		for _, file := range backup.Files {
+			if isHistoryFile(file) {
+				t.Errorf("backup #%d contains the history file", backup.Index)
+			}
+		}
+	}
+}
+
+func TestRevertChange(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry ChangeEntry
+		want  map[string]interface{}
+	}{
+		{"changed", ChangeEntry{Path: "a", Old: 1, New: 2}, map[string]interface{}{"a": 1, "b": 5}},
+		{"added", ChangeEntry{Path: "b", New: 5}, map[string]interface{}{"a": 2}},
+		{"removed", ChangeEntry{Path: "c", Old: "x"}, map[string]interface{}{"a": 2, "b": 5, "c": "x"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &models.UserConfig{Values: map[string]interface{}{"a": 2, "b": 5}}
+			RevertChange(config, tt.entry)
+			if !reflect.DeepEqual(config.Values, tt.want) {
+				t.Errorf("values = %v, want %v", config.Values, tt.want)
+			}
+		})
+	}
+
+	config := &models.UserConfig{}
+	RevertChange(config, ChangeEntry{Path: "a", Old: 1})
+	if config.Values["a"] != 1 {
+		t.Errorf("revert into an empty config = %v", config.Values)
+	}
+}
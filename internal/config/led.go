@@ -0,0 +1,137 @@
+package config
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strings"
+	"time"
+
+	"configcraft/internal/models"
+)
+
+// ledRestartDelay 有限次数的灯效播放完后熄灭的时间，之后预览从头重播
+const ledRestartDelay = time.Second
+
+// ledColors 灯效中可以使用的颜色名称
+var ledColors = map[string]color.NRGBA{
+	"red":    {R: 0xf4, G: 0x43, B: 0x36, A: 0xff},
+	"green":  {R: 0x4c, G: 0xaf, B: 0x50, A: 0xff},
+	"blue":   {R: 0x21, G: 0x96, B: 0xf3, A: 0xff},
+	"orange": {R: 0xff, G: 0x98, B: 0x00, A: 0xff},
+	"yellow": {R: 0xff, G: 0xeb, B: 0x3b, A: 0xff},
+	"purple": {R: 0x9c, G: 0x27, B: 0xb0, A: 0xff},
+	"cyan":   {R: 0x00, G: 0xbc, B: 0xd4, A: 0xff},
+	"white":  {R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+}
+
+// ParseLedColor 解析灯效颜色：颜色名称（不区分大小写）或#RRGGBB
+func ParseLedColor(name string) (color.NRGBA, error) {
+	if c, exists := ledColors[strings.ToLower(name)]; exists {
+		return c, nil
+	}
+	if c, ok := ParseColor(name); ok {
+		return c, nil
+	}
+	return color.NRGBA{}, fmt.Errorf("unknown LED color %q, expected a color name or #RRGGBB", name)
+}
+
+// LedAnimated 灯效是否随时间变化：熄灭和常亮的灯效不需要动画
+func LedAnimated(animation models.LedAnimation) bool {
+	return len(animation.Colors) > 0 && (animation.Breathe || animation.Off > 0 || len(animation.Colors) > 1)
+}
+
+// LedState 灯效开始elapsed之后LED的状态：亮时返回颜色和true
+// 呼吸灯的亮度体现在颜色的透明度上：点亮的前半段由0渐亮到最亮，后半段渐暗回0；
+// 有限次数的灯效播放完后熄灭ledRestartDelay，然后从头开始，便于在预览中反复观看
+func LedState(animation models.LedAnimation, elapsed time.Duration) (color.NRGBA, bool) {
+	if len(animation.Colors) == 0 {
+		return color.NRGBA{}, false
+	}
+	if !LedAnimated(animation) {
+		c, err := ParseLedColor(animation.Colors[0])
+		return c, err == nil // 常亮
+	}
+
+	step := time.Duration(animation.On+animation.Off) * time.Millisecond
+	if step <= 0 {
+		return color.NRGBA{}, false
+	}
+	cycle := step * time.Duration(len(animation.Colors))
+	if animation.Repeat > 0 {
+		played := cycle * time.Duration(animation.Repeat)
+		elapsed %= played + ledRestartDelay
+		if elapsed >= played {
+			return color.NRGBA{}, false
+		}
+	}
+	elapsed %= cycle
+
+	on := time.Duration(animation.On) * time.Millisecond
+	if elapsed%step >= on {
+		return color.NRGBA{}, false
+	}
+	c, err := ParseLedColor(animation.Colors[elapsed/step])
+	if err != nil {
+		return color.NRGBA{}, false
+	}
+	if animation.Breathe {
+		phase := float64(elapsed%step) / float64(on)
+		c.A = uint8(math.Round(float64(c.A) * (1 - math.Abs(2*phase-1))))
+		if c.A == 0 {
+			return color.NRGBA{}, false
+		}
+	}
+	return c, true
+}
+
+// OptionAnimation 值对应的选项的灯效，该值不是选项或选项没有灯效时返回nil
+func OptionAnimation(field models.ConfigField, value interface{}) *models.LedAnimation {
+	for _, option := range field.Options {
+		if fmt.Sprintf("%v", option.Value) == fmt.Sprintf("%v", value) {
+			return option.Animation
+		}
+	}
+	return nil
+}
+
+// HasAnimations 字段是否有带灯效的选项
+func HasAnimations(field models.ConfigField) bool {
+	for _, option := range field.Options {
+		if option.Animation != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// checkAnimations 检查选项的灯效：颜色可以识别，时间不为负，闪烁和呼吸的灯效有点亮时间
+func checkAnimations(schema *models.Schema) error {
+	var err error
+	ForEachField(schema, func(path string, field models.ConfigField) {
+		for _, option := range field.Options {
+			if err != nil || option.Animation == nil {
+				continue
+			}
+			if e := checkAnimation(*option.Animation); e != nil {
+				err = fmt.Errorf("field %s, option %v: %w", path, option.Value, e)
+			}
+		}
+	})
+	return err
+}
+
+func checkAnimation(animation models.LedAnimation) error {
+	for _, name := range animation.Colors {
+		if _, err := ParseLedColor(name); err != nil {
+			return err
+		}
+	}
+	if animation.On < 0 || animation.Off < 0 || animation.Repeat < 0 {
+		return fmt.Errorf("on, off and repeat must not be negative")
+	}
+	if animation.On == 0 && LedAnimated(animation) {
+		return fmt.Errorf("flashing or breathing animation needs an on time")
+	}
+	return nil
+}
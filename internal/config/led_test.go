@@ -0,0 +1,105 @@
+package config
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+	"time"
+
+	"configcraft/internal/models"
+)
+
+func TestLedState(t *testing.T) {
+	ms := time.Millisecond
+	red, blue, green := ledColors["red"], ledColors["blue"], ledColors["green"]
+	// dim 亮度为alpha的颜色
+	dim := func(c color.NRGBA, alpha uint8) color.NRGBA {
+		c.A = alpha
+		return c
+	}
+
+	tests := []struct {
+		name      string
+		animation models.LedAnimation
+		elapsed   time.Duration
+		lit       bool
+		color     color.NRGBA
+	}{
+		{"off", models.LedAnimation{}, 0, false, color.NRGBA{}},
+		{"off with timings", models.LedAnimation{On: 100, Off: 100}, 50 * ms, false, color.NRGBA{}},
+		{"steady", models.LedAnimation{Colors: []string{"blue"}}, 0, true, blue},
+		{"steady much later", models.LedAnimation{Colors: []string{"blue"}}, time.Hour, true, blue},
+		{"steady unknown color", models.LedAnimation{Colors: []string{"teal"}}, 0, false, color.NRGBA{}},
+
+		// 亮100ms、灭200ms
+		{"blink start", models.LedAnimation{Colors: []string{"red"}, On: 100, Off: 200}, 0, true, red},
+		{"blink end of on", models.LedAnimation{Colors: []string{"red"}, On: 100, Off: 200}, 99 * ms, true, red},
+		{"blink start of off", models.LedAnimation{Colors: []string{"red"}, On: 100, Off: 200}, 100 * ms, false, color.NRGBA{}},
+		{"blink end of off", models.LedAnimation{Colors: []string{"red"}, On: 100, Off: 200}, 299 * ms, false, color.NRGBA{}},
+		{"blink next cycle", models.LedAnimation{Colors: []string{"red"}, On: 100, Off: 200}, 300 * ms, true, red},
+
+		// 红蓝交替
+		{"alternate first", models.LedAnimation{Colors: []string{"red", "blue"}, On: 100, Off: 100}, 0, true, red},
+		{"alternate gap", models.LedAnimation{Colors: []string{"red", "blue"}, On: 100, Off: 100}, 100 * ms, false, color.NRGBA{}},
+		{"alternate second", models.LedAnimation{Colors: []string{"red", "blue"}, On: 100, Off: 100}, 200 * ms, true, blue},
+		{"alternate wraps", models.LedAnimation{Colors: []string{"red", "blue"}, On: 100, Off: 100}, 400 * ms, true, red},
+		{"alternate without off", models.LedAnimation{Colors: []string{"red", "blue"}, On: 100}, 100 * ms, true, blue},
+
+		// 闪3次共1200ms，之后熄灭ledRestartDelay再重播
+		{"repeat last flash", models.LedAnimation{Colors: []string{"blue"}, On: 200, Off: 200, Repeat: 3}, 800 * ms, true, blue},
+		{"repeat played", models.LedAnimation{Colors: []string{"blue"}, On: 200, Off: 200, Repeat: 3}, 1200 * ms, false, color.NRGBA{}},
+		{"repeat restart delay", models.LedAnimation{Colors: []string{"blue"}, On: 200, Off: 200, Repeat: 3}, 1200*ms + ledRestartDelay - ms, false, color.NRGBA{}},
+		{"repeat restarted", models.LedAnimation{Colors: []string{"blue"}, On: 200, Off: 200, Repeat: 3}, 1200*ms + ledRestartDelay, true, blue},
+
+		// 呼吸：1秒内渐亮再渐暗，之后灭500ms
+		{"breathe start", models.LedAnimation{Colors: []string{"green"}, On: 1000, Off: 500, Breathe: true}, 0, false, color.NRGBA{}},
+		{"breathe rising", models.LedAnimation{Colors: []string{"green"}, On: 1000, Off: 500, Breathe: true}, 250 * ms, true, dim(green, 0x80)},
+		{"breathe peak", models.LedAnimation{Colors: []string{"green"}, On: 1000, Off: 500, Breathe: true}, 500 * ms, true, green},
+		{"breathe falling", models.LedAnimation{Colors: []string{"green"}, On: 1000, Off: 500, Breathe: true}, 750 * ms, true, dim(green, 0x80)},
+		{"breathe off", models.LedAnimation{Colors: []string{"green"}, On: 1000, Off: 500, Breathe: true}, 1000 * ms, false, color.NRGBA{}},
+		{"breathe next cycle", models.LedAnimation{Colors: []string{"green"}, On: 1000, Off: 500, Breathe: true}, 2000 * ms, true, green},
+		{"breathe without off", models.LedAnimation{Colors: []string{"green"}, On: 1000, Breathe: true}, 1500 * ms, true, green},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, lit := LedState(tt.animation, tt.elapsed)
+			if lit != tt.lit || c != tt.color {
+				t.Errorf("LedState(%v) = %v, %v; want %v, %v", tt.elapsed, c, lit, tt.color, tt.lit)
+			}
+		})
+	}
+}
+
+func TestCheckAnimation(t *testing.T) {
+	tests := []struct {
+		name      string
+		animation models.LedAnimation
+		err       string // 为空时应通过检查
+	}{
+		{"off", models.LedAnimation{}, ""},
+		{"steady", models.LedAnimation{Colors: []string{"Blue"}}, ""},
+		{"hex color", models.LedAnimation{Colors: []string{"#FF8000"}, On: 100, Off: 100}, ""},
+		{"breathe", models.LedAnimation{Colors: []string{"green"}, On: 2000, Breathe: true}, ""},
+		{"unknown color", models.LedAnimation{Colors: []string{"teal"}}, `unknown LED color "teal"`},
+		{"negative on", models.LedAnimation{Colors: []string{"red"}, On: -1, Off: 100}, "must not be negative"},
+		{"negative off", models.LedAnimation{Colors: []string{"red"}, On: 100, Off: -100}, "must not be negative"},
+		{"negative repeat", models.LedAnimation{Colors: []string{"red"}, On: 100, Off: 100, Repeat: -3}, "must not be negative"},
+		{"blink without on", models.LedAnimation{Colors: []string{"red"}, Off: 100}, "needs an on time"},
+		{"alternate without on", models.LedAnimation{Colors: []string{"red", "blue"}}, "needs an on time"},
+		{"breathe without on", models.LedAnimation{Colors: []string{"green"}, Breathe: true}, "needs an on time"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkAnimation(tt.animation)
+			if tt.err == "" {
+				if err != nil {
+					t.Errorf("checkAnimation = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("checkAnimation = %v, want an error containing %q", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package config
+
+import (
+	"fmt"
+
+	"configcraft/internal/models"
+)
+
+// LayoutMatrix 子分组按行列显示为表格，如按键动作：手势（单击、双击、长按）× 左右耳
+// 每个字段用row、column指明所在的单元格，group的rows、columns可以指定行列的顺序和名称
+const LayoutMatrix = "matrix"
+
+// MatrixAxes matrix布局的行和列：先是group中声明的，然后是字段引用但没有声明的（按字段键的顺序，以键作为名称）
+func MatrixAxes(group models.ConfigGroup) (rows, columns []models.MatrixAxis) {
+	rows = append(rows, group.Rows...)
+	columns = append(columns, group.Columns...)
+	seenRows := axisKeys(group.Rows)
+	seenColumns := axisKeys(group.Columns)
+	for _, fieldKey := range sortedKeys(group.Fields) {
+		field := group.Fields[fieldKey]
+		if field.Row != "" && !seenRows[field.Row] {
+			seenRows[field.Row] = true
+			rows = append(rows, models.MatrixAxis{Key: field.Row, Label: models.NewText(field.Row)})
+		}
+		if field.Column != "" && !seenColumns[field.Column] {
+			seenColumns[field.Column] = true
+			columns = append(columns, models.MatrixAxis{Key: field.Column, Label: models.NewText(field.Column)})
+		}
+	}
+	return rows, columns
+}
+
+// MatrixCells 每个单元格中的字段：行键 -> 列键 -> 字段键
+func MatrixCells(group models.ConfigGroup) map[string]map[string]string {
+	cells := make(map[string]map[string]string)
+	for fieldKey, field := range group.Fields {
+		if cells[field.Row] == nil {
+			cells[field.Row] = make(map[string]string)
+		}
+		cells[field.Row][field.Column] = fieldKey
+	}
+	return cells
+}
+
+// CopyMatrixRow 把from行各列的当前值（未设置时为默认值）复制到to行同一列的字段
+// 返回要写入的值（按字段路径）；to行的select字段没有该选项时跳过，返回跳过的字段路径
+func CopyMatrixRow(group models.ConfigGroup, groupPath string, values map[string]interface{}, from, to string) (map[string]interface{}, []string) {
+	cells := MatrixCells(group)
+	_, columns := MatrixAxes(group)
+
+	assignments := make(map[string]interface{})
+	var skipped []string
+	for _, column := range columns {
+		source, hasSource := cells[from][column.Key]
+		target, hasTarget := cells[to][column.Key]
+		if !hasSource || !hasTarget {
+			continue
+		}
+		value, exists := values[groupPath+"."+source]
+		if !exists {
+			value = group.Fields[source].Default
+		}
+		targetField := group.Fields[target]
+		if value == nil || (targetField.Type == "select" && !hasOption(targetField, value)) {
+			skipped = append(skipped, groupPath+"."+target)
+			continue
+		}
+		assignments[groupPath+"."+target] = value
+	}
+	return assignments, skipped
+}
+
+// checkLayout 检查子分组的显示方式：matrix布局中每个字段都是带选项的select或combo，且占据不同的单元格
+func checkLayout(schema *models.Schema) error {
+	for _, sectionKey := range sortedKeys(schema.Sections) {
+		section := schema.Sections[sectionKey]
+		for _, groupKey := range sortedKeys(section.Groups) {
+			group := section.Groups[groupKey]
+			groupPath := sectionKey + "." + groupKey
+			switch group.Layout {
+			case "":
+				continue
+			case LayoutMatrix:
+			default:
+				return fmt.Errorf("group %s: unknown layout %q, expected %s", groupPath, group.Layout, LayoutMatrix)
+			}
+			if err := checkMatrix(group, groupPath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func checkMatrix(group models.ConfigGroup, groupPath string) error {
+	for name, axes := range map[string][]models.MatrixAxis{"row": group.Rows, "column": group.Columns} {
+		seen := make(map[string]bool)
+		for _, axis := range axes {
+			if axis.Key == "" {
+				return fmt.Errorf("group %s: %s without a key", groupPath, name)
+			}
+			if seen[axis.Key] {
+				return fmt.Errorf("group %s: duplicate %s %q", groupPath, name, axis.Key)
+			}
+			seen[axis.Key] = true
+		}
+	}
+
+	declaredRows := axisKeys(group.Rows)
+	declaredColumns := axisKeys(group.Columns)
+	owners := make(map[[2]string]string)
+	for _, fieldKey := range sortedKeys(group.Fields) {
+		field := group.Fields[fieldKey]
+		path := groupPath + "." + fieldKey
+		switch {
+		case field.Row == "" || field.Column == "":
+			return fmt.Errorf("field %s: matrix layout requires row and column", path)
+		case len(group.Rows) > 0 && !declaredRows[field.Row]:
+			return fmt.Errorf("field %s: row %q is not declared in group rows", path, field.Row)
+		case len(group.Columns) > 0 && !declaredColumns[field.Column]:
+			return fmt.Errorf("field %s: column %q is not declared in group columns", path, field.Column)
+		case (field.Type != "select" && field.Type != "combo") || len(field.Options) == 0:
+			return fmt.Errorf("field %s: matrix cells must be select or combo fields with options", path)
+		}
+		cell := [2]string{field.Row, field.Column}
+		if owner, exists := owners[cell]; exists {
+			return fmt.Errorf("fields %s and %s are both in row %q, column %q", owner, path, field.Row, field.Column)
+		}
+		owners[cell] = path
+	}
+	return nil
+}
+
+func axisKeys(axes []models.MatrixAxis) map[string]bool {
+	keys := make(map[string]bool, len(axes))
+	for _, axis := range axes {
+		keys[axis.Key] = true
+	}
+	return keys
+}
@@ -0,0 +1,159 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"configcraft/internal/models"
+)
+
+// matrixSchema 按键动作：手势 × 左右耳，长按只有左耳
+const matrixSchema = `sections:
+  key_actions:
+    groups:
+      idle:
+        layout: matrix
+        rows:
+          - {key: double, label: 双击}
+          - {key: click, label: 单击}
+          - {key: long, label: 长按}
+        columns:
+          - {key: left, label: 左耳}
+          - {key: right, label: 右耳}
+        fields:
+          click_l: {type: select, row: click, column: left, default: PLAY, options: [{value: PLAY}, {value: VOL_UP}, {value: ANC}]}
+          click_r: {type: select, row: click, column: right, default: NEXT, options: [{value: NEXT}, {value: PREV}]}
+          double_l: {type: combo, row: double, column: left, options: [{value: VOL_UP}]}
+          double_r: {type: combo, row: double, column: right, options: [{value: VOL_DOWN}]}
+          long_l: {type: select, row: long, column: left, default: ANC, options: [{value: ANC}]}
+`
+
+func matrixGroup(t *testing.T) models.ConfigGroup {
+	t.Helper()
+	return loadTestSchema(t, matrixSchema).Sections["key_actions"].Groups["idle"]
+}
+
+func TestMatrixAxes(t *testing.T) {
+	axisNames := func(axes []models.MatrixAxis) []string {
+		var names []string
+		for _, axis := range axes {
+			names = append(names, axis.Key+":"+axis.Label.String())
+		}
+		return names
+	}
+
+	// 声明的行按声明的顺序和名称
+	rows, columns := MatrixAxes(matrixGroup(t))
+	if got, want := axisNames(rows), []string{"double:双击", "click:单击", "long:长按"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rows = %v, want %v", got, want)
+	}
+	if got, want := axisNames(columns), []string{"left:左耳", "right:右耳"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("columns = %v, want %v", got, want)
+	}
+
+	// 没有声明时按字段键的顺序，以键作为名称
+	group := matrixGroup(t)
+	group.Rows = group.Rows[:1]
+	group.Columns = nil
+	rows, columns = MatrixAxes(group)
+	if got, want := axisNames(rows), []string{"double:双击", "click:click", "long:long"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("partly declared rows = %v, want %v", got, want)
+	}
+	if got, want := axisNames(columns), []string{"left:left", "right:right"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("undeclared columns = %v, want %v", got, want)
+	}
+
+	cells := MatrixCells(matrixGroup(t))
+	if cells["click"]["right"] != "click_r" || cells["long"]["left"] != "long_l" || cells["long"]["right"] != "" {
+		t.Errorf("cells = %v", cells)
+	}
+}
+
+func TestCopyMatrixRow(t *testing.T) {
+	const groupPath = "key_actions.idle"
+	tests := []struct {
+		name     string
+		values   map[string]interface{}
+		from, to string
+		want     map[string]interface{}
+		skipped  []string
+	}{
+		{
+			name:   "current values",
+			values: map[string]interface{}{groupPath + ".double_l": "VOL_UP", groupPath + ".double_r": "CUSTOM"},
+			from:   "double", to: "click",
+			// double_r是combo，可以有自定义值；click_r是select，没有CUSTOM选项
+			want:    map[string]interface{}{groupPath + ".click_l": "VOL_UP"},
+			skipped: []string{groupPath + ".click_r"},
+		},
+		{
+			name: "defaults",
+			from: "click", to: "double",
+			// 复制到combo时不限于预设选项
+			want: map[string]interface{}{groupPath + ".double_l": "PLAY", groupPath + ".double_r": "NEXT"},
+		},
+		{
+			name: "unset without default",
+			from: "double", to: "long",
+			skipped: []string{groupPath + ".long_l"},
+		},
+		{
+			name: "missing cells",
+			from: "long", to: "click",
+			// long行只有左耳
+			want: map[string]interface{}{groupPath + ".click_l": "ANC"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assignments, skipped := CopyMatrixRow(matrixGroup(t), groupPath, tt.values, tt.from, tt.to)
+			if tt.want == nil {
+				tt.want = map[string]interface{}{}
+			}
+			if !reflect.DeepEqual(assignments, tt.want) || !reflect.DeepEqual(skipped, tt.skipped) {
+				t.Errorf("CopyMatrixRow = %v, skipped %v; want %v, skipped %v", assignments, skipped, tt.want, tt.skipped)
+			}
+		})
+	}
+}
+
+func TestCheckLayout(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string // 把matrixSchema中的old替换为new
+		err      string // 为空时应通过检查
+	}{
+		{"valid", "", "", ""},
+		{"unknown layout", "layout: matrix", "layout: grid", `unknown layout "grid"`},
+		{"row without key", "- {key: double, label: 双击}", "- {label: 双击}", "row without a key"},
+		{"duplicate column", "- {key: right, label: 右耳}", "- {key: left, label: 右耳}", `duplicate column "left"`},
+		{"undeclared row", "row: long, column: left, default: ANC", "row: triple, column: left, default: ANC", `row "triple" is not declared`},
+		{"undeclared column", "column: left, default: ANC", "column: middle, default: ANC", `column "middle" is not declared`},
+		{"missing column", "row: long, column: left, default: ANC", "row: long, default: ANC", "requires row and column"},
+		{"not a select", "long_l: {type: select", "long_l: {type: string", "must be select or combo"},
+		{"no options", "double_r: {type: combo, row: double, column: right, options: [{value: VOL_DOWN}]}", "double_r: {type: combo, row: double, column: right}", "must be select or combo"},
+		{"same cell", "row: long, column: left, default: ANC", "row: click, column: left, default: ANC", `both in row "click", column "left"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := matrixSchema
+			if tt.old != "" {
+				if !strings.Contains(content, tt.old) {
+					t.Fatalf("schema does not contain %q", tt.old)
+				}
+				content = strings.Replace(content, tt.old, tt.new, 1)
+			}
+			err := checkLayout(loadTestSchema(t, content))
+			if tt.err == "" {
+				if err != nil {
+					t.Errorf("checkLayout = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("checkLayout = %v, want an error containing %q", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"configcraft/internal/models"
+)
+
+// 命名规则中键名的大小写
+const (
+	NamingUpper = "upper" // 转为大写（默认）
+	NamingLower = "lower" // 转为小写
+	NamingKeep  = "keep"  // 保持YAML中的写法
+)
+
+// defaultKeyTemplate 默认的键名模板
+// key -> _KEY, section.field -> _SECTION_FIELD, section.group.field -> _SECTION_GROUP_FIELD
+const defaultKeyTemplate = "_{path}"
+
+// OutputKey 返回配置路径在生成文件中的键名
+// 字段声明了output_key时直接使用，否则按schema的命名规则生成；导入和所有生成器都使用此规则
+func OutputKey(schema *models.Schema, path string) string {
+	if field, exists := LookupField(schema, path); exists && field.OutputKey != "" {
+		return field.OutputKey
+	}
+
+	var rule models.NamingRule
+	if schema != nil {
+		rule = schema.Naming
+	}
+	return applyNamingRule(rule, path)
+}
+
+// applyNamingRule 按命名规则生成键名
+// 模板中可使用 {path}（完整路径，点号替换为分隔符）、{section}（第一级）和 {field}（最后一级）
+func applyNamingRule(rule models.NamingRule, path string) string {
+	template := rule.KeyTemplate
+	if template == "" {
+		template = defaultKeyTemplate
+	}
+	separator := rule.Separator
+	if separator == "" {
+		separator = "_"
+	}
+	convert := func(s string) string {
+		switch rule.Case {
+		case NamingLower:
+			return strings.ToLower(s)
+		case NamingKeep:
+			return s
+		}
+		return strings.ToUpper(s)
+	}
+
+	parts := strings.Split(path, ".")
+	return strings.NewReplacer(
+		"{path}", convert(strings.Join(parts, separator)),
+		"{section}", convert(parts[0]),
+		"{field}", convert(parts[len(parts)-1]),
+	).Replace(template)
+}
+
+// pathFromOutputKey 把无法对应到已知字段的键名还原为一级配置路径
+// 去掉模板中{path}前后的固定部分并还原大小写，使再次生成时得到相同的键名
+func pathFromOutputKey(rule models.NamingRule, key string) string {
+	template := rule.KeyTemplate
+	if template == "" {
+		template = defaultKeyTemplate
+	}
+	if prefix, suffix, found := strings.Cut(template, "{path}"); found && !strings.Contains(prefix+suffix, "{") {
+		key = strings.TrimSuffix(strings.TrimPrefix(key, prefix), suffix)
+	}
+
+	if rule.Case == "" || rule.Case == NamingUpper {
+		return strings.ToLower(key)
+	}
+	return key
+}
+
+// checkNaming 检查schema的命名规则：大小写取值合法，且不同字段不会生成相同的键名
+func checkNaming(schema *models.Schema) error {
+	switch schema.Naming.Case {
+	case "", NamingUpper, NamingLower, NamingKeep:
+	default:
+		return fmt.Errorf("invalid naming case %q, expected %s, %s or %s", schema.Naming.Case, NamingUpper, NamingLower, NamingKeep)
+	}
+
+	owners := make(map[string]string)
+	var err error
+	ForEachField(schema, func(path string, field models.ConfigField) {
+		key := OutputKey(schema, path)
+		if owner, exists := owners[key]; exists && err == nil {
+			err = fmt.Errorf("fields %s and %s both use output key %s", owner, path, key)
+		}
+		owners[key] = path
+	})
+	return err
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"configcraft/internal/models"
+)
+
+func TestOutputKey(t *testing.T) {
+	schema := loadTestSchema(t, `sections:
+  basic:
+    fields:
+      volume:
+        type: number
+      mic:
+        type: string
+        output_key: MIC_GAIN
+    groups:
+      eq:
+        fields:
+          bass:
+            type: number
+`)
+
+	tests := []struct {
+		name   string
+		naming models.NamingRule
+		path   string
+		want   string
+	}{
+		{"default", models.NamingRule{}, "basic.volume", "_BASIC_VOLUME"},
+		{"group field", models.NamingRule{}, "basic.eq.bass", "_BASIC_EQ_BASS"},
+		{"top level", models.NamingRule{}, "version", "_VERSION"},
+		{"output_key wins", models.NamingRule{KeyTemplate: "CFG_{path}"}, "basic.mic", "MIC_GAIN"},
+		{"template", models.NamingRule{KeyTemplate: "CFG_{path}"}, "basic.volume", "CFG_BASIC_VOLUME"},
+		{"section and field", models.NamingRule{KeyTemplate: "{section}__{field}"}, "basic.eq.bass", "BASIC__BASS"},
+		{"separator", models.NamingRule{Separator: "."}, "basic.volume", "_BASIC.VOLUME"},
+		{"lower", models.NamingRule{Case: NamingLower}, "basic.Volume", "_basic_volume"},
+		{"keep", models.NamingRule{Case: NamingKeep}, "basic.Volume", "_basic_Volume"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema.Naming = tt.naming
+			if got := OutputKey(schema, tt.path); got != tt.want {
+				t.Errorf("OutputKey(%s) = %s, want %s", tt.path, got, tt.want)
+			}
+		})
+	}
+
+	if got := OutputKey(nil, "basic.volume"); got != "_BASIC_VOLUME" {
+		t.Errorf("OutputKey without schema = %s", got)
+	}
+}
+
+func TestPathFromOutputKey(t *testing.T) {
+	tests := []struct {
+		naming models.NamingRule
+		key    string
+		want   string
+	}{
+		{models.NamingRule{}, "_VERSION", "version"},
+		{models.NamingRule{KeyTemplate: "{path}"}, "VERSION", "version"},
+		{models.NamingRule{KeyTemplate: "CFG_{path}_V1"}, "CFG_VERSION_V1", "version"},
+		{models.NamingRule{KeyTemplate: "{path}", Case: NamingKeep}, "Version", "Version"},
+	}
+	for _, tt := range tests {
+		got := pathFromOutputKey(tt.naming, tt.key)
+		if got != tt.want {
+			t.Errorf("pathFromOutputKey(%+v, %s) = %s, want %s", tt.naming, tt.key, got, tt.want)
+		}
+		// 还原的路径再次生成时得到相同的键名
+		if key := applyNamingRule(tt.naming, got); key != tt.key {
+			t.Errorf("applyNamingRule(%s) = %s, want %s", got, key, tt.key)
+		}
+	}
+}
+
+func TestCheckNaming(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		err    string // 为空表示没有错误
+	}{
+		{
+			name: "valid",
+			schema: `naming: {case: lower}
+sections:
+  basic:
+    fields:
+      volume: {type: number}
+      mode: {type: string}
+`,
+		},
+		{
+			name: "invalid case",
+			schema: `naming: {case: title}
+sections: {}
+`,
+			err: "invalid naming case",
+		},
+		{
+			name: "template without path",
+			schema: `naming: {key_template: "{section}_{field}"}
+sections:
+  basic:
+    fields:
+      volume: {type: number}
+    groups:
+      eq:
+        fields:
+          volume: {type: number}
+`,
+			err: "both use output key BASIC_VOLUME",
+		},
+		{
+			name: "output_key collision",
+			schema: `sections:
+  basic:
+    fields:
+      volume: {type: number}
+      level: {type: number, output_key: _BASIC_VOLUME}
+`,
+			err: "both use output key _BASIC_VOLUME",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkNaming(loadTestSchema(t, tt.schema))
+			switch {
+			case tt.err == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+				t.Errorf("err = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
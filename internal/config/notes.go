@@ -0,0 +1,59 @@
+package config
+
+import (
+	"strings"
+	"time"
+
+	"configcraft/internal/models"
+)
+
+// NoteDateLayout 备注日期的格式
+const NoteDateLayout = "2006-01-02"
+
+// NewFieldNote 当前用户在今天写下的备注
+func NewFieldNote(text string) models.FieldNote {
+	return models.FieldNote{Note: text, Author: currentUser(), Date: time.Now().Format(NoteDateLayout)}
+}
+
+// SetFieldNote 设置配置项的备注，备注文字为空时删除
+func SetFieldNote(config *models.UserConfig, path string, note models.FieldNote) {
+	note.Note = strings.TrimSpace(note.Note)
+	if note.Note == "" {
+		delete(config.Notes, path)
+		return
+	}
+	if config.Notes == nil {
+		config.Notes = make(map[string]models.FieldNote)
+	}
+	config.Notes[path] = note
+}
+
+// NoteAttribution 备注的作者和日期，如"alice, 2024-05-01"，都没有时为空
+func NoteAttribution(note models.FieldNote) string {
+	var parts []string
+	for _, part := range []string{note.Author, note.Date} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// NoteComment 把备注格式化为以prefix开头的注释行，作者和日期附在最后一行后面
+// 每行以换行结尾，便于直接放在配置行之前；没有备注时为空
+func NoteComment(prefix string, note models.FieldNote) string {
+	text := strings.TrimSpace(note.Note)
+	if text == "" {
+		return ""
+	}
+	if attribution := NoteAttribution(note); attribution != "" {
+		text += " (" + attribution + ")"
+	}
+
+	var out strings.Builder
+	for _, line := range strings.Split(text, "\n") {
+		out.WriteString(strings.TrimRight(prefix+" "+strings.TrimSpace(line), " "))
+		out.WriteString("\n")
+	}
+	return out.String()
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"configcraft/internal/models"
+)
+
+func TestNoteComment(t *testing.T) {
+	tests := []struct {
+		name string
+		note models.FieldNote
+		want string
+	}{
+		{"empty", models.FieldNote{Author: "alice"}, ""},
+		{"text only", models.FieldNote{Note: "客户要求"}, "# 客户要求\n"},
+		{"attribution", models.FieldNote{Note: "客户要求", Author: "alice", Date: "2025-09-01"}, "# 客户要求 (alice, 2025-09-01)\n"},
+		{"multiple lines", models.FieldNote{Note: " 第一行\n\n  第二行 ", Date: "2025-09-01"}, "# 第一行\n#\n# 第二行 (2025-09-01)\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NoteComment("#", tt.note); got != tt.want {
+				t.Errorf("NoteComment = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetFieldNote(t *testing.T) {
+	config := &models.UserConfig{}
+	SetFieldNote(config, "basic.level", models.FieldNote{Note: "  调高  "})
+	if got := config.Notes["basic.level"].Note; got != "调高" {
+		t.Errorf("note = %q", got)
+	}
+	SetFieldNote(config, "basic.level", models.FieldNote{Note: " "})
+	if _, exists := config.Notes["basic.level"]; exists {
+		t.Error("blank note was not removed")
+	}
+}
+
+func TestNotesInConf(t *testing.T) {
+	dir := t.TempDir()
+	p := NewParser()
+	if err := p.LoadSchema(writeSchema(t, dir, testSchema)); err != nil {
+		t.Fatal(err)
+	}
+	confPath := filepath.Join(dir, "cfg.conf")
+	config := &models.UserConfig{Values: map[string]interface{}{"basic.level": 3}}
+	SetFieldNote(config, "basic.level", models.FieldNote{Note: "客户要求", Author: "alice"})
+	if err := p.GenerateConfFile(config, confPath, OutputFull); err != nil {
+		t.Fatal(err)
+	}
+
+	if conf := readFile(t, confPath); !strings.Contains(conf, "# 客户要求 (alice)\n_BASIC_LEVEL=3\n") {
+		t.Errorf("note is not written before the value:\n%s", conf)
+	}
+	// 备注是注释，导入时被忽略
+	imported, _, err := ImportConfFile(confPath, p.GetSchema(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(imported.Values) != 1 || imported.Values["basic.level"] != 3 {
+		t.Errorf("imported values = %v", imported.Values)
+	}
+}
@@ -4,15 +4,17 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
-	"time"
 
 	"configcraft/internal/models"
 	"gopkg.in/yaml.v3"
 )
 
 type Parser struct {
-	schema *models.Schema
+	schema      *models.Schema
+	schemaDir   string // schema文件所在目录，schema中的相对模板路径相对于它
+	backupCount int    // 保存时保留的历史版本数，0表示不备份
 }
 
 func NewParser() *Parser {
@@ -34,8 +36,21 @@ func (p *Parser) LoadSchema(filePath string) error {
 	if len(schema.Sections) == 0 {
 		return fmt.Errorf("file does not contain valid schema sections")
 	}
+	if err := checkNaming(&schema); err != nil {
+		return fmt.Errorf("invalid schema naming: %w", err)
+	}
+	if err := checkLayout(&schema); err != nil {
+		return fmt.Errorf("invalid schema layout: %w", err)
+	}
+	if err := checkFieldTypes(&schema); err != nil {
+		return fmt.Errorf("invalid schema field: %w", err)
+	}
+	if err := checkAnimations(&schema); err != nil {
+		return fmt.Errorf("invalid LED animation: %w", err)
+	}
 
 	p.schema = &schema
+	p.schemaDir = filepath.Dir(filePath)
 	return nil
 }
 
@@ -43,123 +58,90 @@ func (p *Parser) GetSchema() *models.Schema {
 	return p.schema
 }
 
+// SetBackupCount 设置保存时保留的.bak历史版本数，0表示不备份
+func (p *Parser) SetBackupCount(count int) {
+	p.backupCount = count
+}
+
 func (p *Parser) LoadUserConfig(filePath string) (*models.UserConfig, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read user config file: %w", err)
 	}
 
+	// 保留原始文档树，保存时据此保留注释和格式
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse user config: %w", err)
+	}
+
 	var config models.UserConfig
-	if err := yaml.Unmarshal(data, &config); err != nil {
+	if err := doc.Decode(&config); err != nil && doc.Kind != 0 {
 		return nil, fmt.Errorf("failed to parse user config: %w", err)
 	}
 
 	if config.Values == nil {
 		config.Values = make(map[string]interface{})
 	}
+	config.Source = &doc
+	config.FilePath = filePath
 
 	return &config, nil
 }
 
+// SaveUserConfig 保存YAML配置，并把相对磁盘上版本的修改追加到变更记录
 func (p *Parser) SaveUserConfig(config *models.UserConfig, filePath string) error {
-	data, err := yaml.Marshal(config)
+	data, err := p.renderUserConfig(config)
 	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
+		return err
 	}
 
-	if err := os.WriteFile(filePath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write config file: %w", err)
+	files := map[string][]byte{filePath: data}
+	if err := p.addChangeLog(files, config.Values, filePath); err != nil {
+		return err
 	}
+	return p.commitFiles(filePath, files)
+}
 
-	return nil
+// renderUserConfig 将用户配置序列化为YAML内容
+// 从文件加载的配置在原文档树上修改，以保留注释、键顺序和格式
+func (p *Parser) renderUserConfig(config *models.UserConfig) ([]byte, error) {
+	if config.Source != nil {
+		return patchUserConfig(config)
+	}
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	return data, nil
 }
 
 // GenerateConfFile 根据用户配置生成DHF conf文件 - 通用版本
-func (p *Parser) GenerateConfFile(config *models.UserConfig, filePath string) error {
-	var confLines []string
-	
-	// 文件头部注释
-	confLines = append(confLines, "#")
-	confLines = append(confLines, "#  @file    dhf_config.conf")
-	confLines = append(confLines, "#  @brief   Configuration File")
-	confLines = append(confLines, "#  @note    Generated by ConfigCraft")
-	confLines = append(confLines, "#           Created by Felix")
-	confLines = append(confLines, fmt.Sprintf("#           Generated on %s", time.Now().Format("2006-01-02 15:04:05")))
-	confLines = append(confLines, "#")
-	confLines = append(confLines, "")
-	confLines = append(confLines, "#***************************************************************************")
-	confLines = append(confLines, "#                       Configuration Settings")
-	confLines = append(confLines, "#***************************************************************************")
-	confLines = append(confLines, "")
-
-	// 按原始顺序处理配置（不排序以保持YAML文件中的顺序）
-	var allKeys []string
-	for key := range config.Values {
-		allKeys = append(allKeys, key)
-	}
-	// 移除字母排序，保持原始顺序
-
-	currentSection := ""
-	for _, key := range allKeys {
-		value := config.Values[key]
-		
-		// 解析key路径，例如 "basic.ic_model" 或 "key_actions.call_scenario.active_click"
-		parts := strings.Split(key, ".")
-		if len(parts) >= 1 {
-			var sectionKey, confKey string
-			
-			if len(parts) == 1 {
-				// 一级配置：key -> _KEY
-				sectionKey = "GENERAL"
-				confKey = fmt.Sprintf("_%s", strings.ToUpper(parts[0]))
-			} else if len(parts) == 2 {
-				// 二级配置：section.field -> _SECTION_FIELD  
-				sectionKey = strings.ToUpper(parts[0])
-				confKey = fmt.Sprintf("_%s_%s", strings.ToUpper(parts[0]), strings.ToUpper(parts[1]))
-			} else {
-				// 三级或更深配置：section.group.field -> _SECTION_GROUP_FIELD
-				sectionKey = strings.ToUpper(parts[0])
-				// 将所有中间部分和最后部分用下划线连接
-				fieldParts := make([]string, len(parts))
-				for i, part := range parts {
-					fieldParts[i] = strings.ToUpper(part)
-				}
-				confKey = "_" + strings.Join(fieldParts, "_")
-			}
-			
-			// 如果是新的section，添加section注释
-			if currentSection != sectionKey {
-				if currentSection != "" {
-					confLines = append(confLines, "")
-				}
-				
-				// 生成section注释
-				sectionName := p.getSectionName(strings.ToLower(parts[0]))
-				confLines = append(confLines, fmt.Sprintf("# %s", sectionName))
-				confLines = append(confLines, "#" + strings.Repeat("-", len(sectionName)+2))
-				currentSection = sectionKey
-			}
-			
-			// 生成配置行
-			confLines = append(confLines, fmt.Sprintf("%s=%v", confKey, value))
-		}
+// mode为输出模式（OutputFull、OutputWithDefaults、OutputOverrides），为空时输出配置中的所有值
+func (p *Parser) GenerateConfFile(config *models.UserConfig, filePath string, mode string) error {
+	data, err := p.renderConfFile(config, mode, filePath)
+	if err != nil {
+		return err
 	}
-	
-	// 文件尾部
-	confLines = append(confLines, "")
-	confLines = append(confLines, "#***************************************************************************")
-	confLines = append(confLines, "#                       End of Configuration")
-	confLines = append(confLines, "#***************************************************************************")
-
-	// 写入文件
-	content := strings.Join(confLines, "\n")
-	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+
+	if err := p.commitFiles(backupAnchor(config, filePath), map[string][]byte{filePath: data}); err != nil {
 		return fmt.Errorf("failed to write conf file: %w", err)
 	}
 
 	return nil
 }
 
+// renderConfFile 根据用户配置按输出模式生成conf文件filePath的内容
+// 使用schema中为conf格式指定的模板，没有指定时使用内置模板
+func (p *Parser) renderConfFile(config *models.UserConfig, mode, filePath string) ([]byte, error) {
+	templatePath, err := p.templateFor(FormatConf)
+	if err != nil {
+		return nil, err
+	}
+	return p.renderTemplate(templatePath, config, mode, filePath)
+}
+
 // getSectionName 获取section的显示名称
 func (p *Parser) getSectionName(sectionKey string) string {
 	nameMap := map[string]string{
@@ -177,22 +159,198 @@ func (p *Parser) getSectionName(sectionKey string) string {
 	return fmt.Sprintf("%s配置 (%s Configuration)", strings.Title(sectionKey), strings.Title(sectionKey))
 }
 
+// ConfPathFor 返回YAML配置对应的conf文件路径（同目录，扩展名改为.conf）
+func ConfPathFor(yamlPath string) string {
+	dir := filepath.Dir(yamlPath)
+	base := strings.TrimSuffix(filepath.Base(yamlPath), filepath.Ext(yamlPath))
+	return filepath.Join(dir, base+".conf")
+}
+
 // SaveConfigWithConf 保存YAML配置并同时生成conf文件
-func (p *Parser) SaveConfigWithConf(config *models.UserConfig, yamlPath string) error {
-	// 保存YAML文件
-	if err := p.SaveUserConfig(config, yamlPath); err != nil {
+// 两个文件作为一个整体提交：任何一个写入失败，磁盘上的文件都保持原样
+// conf文件在上次生成后被手工修改过时不写入任何文件，返回*ConfModifiedError，
+// 由调用方决定导回修改（ApplyConfEdits）后重新保存，或用OverwriteConfigWithConf覆盖
+// mode为conf文件的输出模式，为空时输出配置中的所有值
+func (p *Parser) SaveConfigWithConf(config *models.UserConfig, yamlPath, mode string) error {
+	edits, err := p.DetectConfEdits(config, yamlPath, mode)
+	if err != nil {
+		return err
+	}
+	if len(edits) > 0 {
+		return &ConfModifiedError{Path: ConfPathFor(yamlPath), Edits: edits}
+	}
+
+	return p.OverwriteConfigWithConf(config, yamlPath, mode)
+}
+
+// OverwriteConfigWithConf 保存YAML配置并生成conf文件，不检查conf文件是否被手工修改
+// 修改同时追加到变更记录（HistoryPathFor），与两个文件一起提交
+func (p *Parser) OverwriteConfigWithConf(config *models.UserConfig, yamlPath, mode string) error {
+	yamlData, err := p.renderUserConfig(config)
+	if err != nil {
 		return fmt.Errorf("failed to save YAML config: %w", err)
 	}
 
-	// 生成对应的conf文件路径（同目录，扩展名改为.conf）
-	dir := filepath.Dir(yamlPath)
-	base := strings.TrimSuffix(filepath.Base(yamlPath), filepath.Ext(yamlPath))
-	confPath := filepath.Join(dir, base+".conf")
+	config.FilePath = yamlPath
+	confData, err := p.renderConfFile(config, mode, ConfPathFor(yamlPath))
+	if err != nil {
+		return err
+	}
+
+	files := map[string][]byte{
+		yamlPath:              yamlData,
+		ConfPathFor(yamlPath): confData,
+	}
+	if err := p.addChangeLog(files, config.Values, yamlPath); err != nil {
+		return err
+	}
+	if err := p.commitFiles(yamlPath, files); err != nil {
+		return fmt.Errorf("failed to save config files: %w", err)
+	}
+
+	return nil
+}
+
+// SaveConfigWithOutputs 保存YAML配置并生成项目中定义的所有输出文件，所有文件（包括变更记录）作为一个整体提交
+// 与SaveConfigWithConf一样，conf格式的输出文件在上次生成后被手工修改过时不写入任何文件，返回*ConfModifiedError
+func (p *Parser) SaveConfigWithOutputs(config *models.UserConfig, yamlPath string, outputs []models.ProjectOutput, settings models.GeneratorSettings) error {
+	if err := p.checkOutputEdits(config, yamlPath, outputs, settings); err != nil {
+		return err
+	}
+
+	return p.OverwriteConfigWithOutputs(config, yamlPath, outputs, settings)
+}
+
+// OverwriteConfigWithOutputs 保存YAML配置并生成所有输出文件，不检查输出文件是否被手工修改
+func (p *Parser) OverwriteConfigWithOutputs(config *models.UserConfig, yamlPath string, outputs []models.ProjectOutput, settings models.GeneratorSettings) error {
+	config.FilePath = yamlPath
+	files, err := p.renderOutputs(config, outputs, settings)
+	if err != nil {
+		return err
+	}
+
+	yamlData, err := p.renderUserConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to save YAML config: %w", err)
+	}
+	files[yamlPath] = yamlData
+	if err := p.addChangeLog(files, config.Values, yamlPath); err != nil {
+		return err
+	}
+
+	if err := p.commitFiles(yamlPath, files); err != nil {
+		return fmt.Errorf("failed to save config files: %w", err)
+	}
 
-	// 生成conf文件
-	if err := p.GenerateConfFile(config, confPath); err != nil {
-		return fmt.Errorf("failed to generate conf file: %w", err)
+	return nil
+}
+
+// GenerateOutputs 只生成输出文件，不修改YAML配置
+// conf格式的输出文件在上次（按配置的来源YAML）生成后被手工修改过时不写入任何文件，返回*ConfModifiedError
+func (p *Parser) GenerateOutputs(config *models.UserConfig, outputs []models.ProjectOutput, settings models.GeneratorSettings) error {
+	if err := p.checkOutputEdits(config, config.FilePath, outputs, settings); err != nil {
+		return err
+	}
+
+	return p.OverwriteOutputs(config, outputs, settings)
+}
+
+// OverwriteOutputs 只生成输出文件，不检查输出文件是否被手工修改
+func (p *Parser) OverwriteOutputs(config *models.UserConfig, outputs []models.ProjectOutput, settings models.GeneratorSettings) error {
+	files, err := p.renderOutputs(config, outputs, settings)
+	if err != nil {
+		return err
+	}
+
+	anchor := config.FilePath
+	if anchor == "" && len(outputs) > 0 {
+		anchor = outputs[0].Path
+	}
+	if err := p.commitFiles(anchor, files); err != nil {
+		return fmt.Errorf("failed to write output files: %w", err)
 	}
 
 	return nil
+}
+
+// renderOutputs 按各输出的格式生成文件内容
+// 输出指定了模板时直接使用该模板，否则按格式查找schema中的模板或内置的conf模板
+func (p *Parser) renderOutputs(config *models.UserConfig, outputs []models.ProjectOutput, settings models.GeneratorSettings) (map[string][]byte, error) {
+	files := make(map[string][]byte, len(outputs))
+	for _, output := range outputs {
+		format, mode := outputFormat(output, settings)
+		templatePath := output.Template
+		if templatePath == "" {
+			var err error
+			if templatePath, err = p.templateFor(format); err != nil {
+				return nil, fmt.Errorf("%s: %w", output.Path, err)
+			}
+		}
+
+		data, err := p.renderTemplate(templatePath, config, mode, output.Path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", output.Path, err)
+		}
+		files[output.Path] = data
+	}
+	return files, nil
+}
+
+// outputFormat 输出使用的格式和输出模式，未单独指定时使用生成设置中的值
+func outputFormat(output models.ProjectOutput, settings models.GeneratorSettings) (format, mode string) {
+	format, mode = output.Format, output.Mode
+	if format == "" {
+		format = settings.Format
+	}
+	if mode == "" {
+		mode = settings.Mode
+	}
+	return format, mode
+}
+
+// backupAnchor 只生成输出文件时备份所属的YAML：配置的来源文件，未保存的配置为输出文件本身
+// 来源YAML会与输出文件一起备份，恢复时二者保持一致
+func backupAnchor(config *models.UserConfig, outputPath string) string {
+	if config.FilePath != "" {
+		return config.FilePath
+	}
+	return outputPath
+}
+
+// commitFiles 通过临时文件+重命名一次性提交所有文件
+// 提交前把这些文件（变更记录除外）和anchor（YAML）的当前内容作为一组备份准备好，放在anchor的备份目录中；
+// 提交成功后这组备份才加入备份链，提交失败时已有的备份保持不变
+func (p *Parser) commitFiles(anchor string, files map[string][]byte) error {
+	// 固定提交顺序，使回滚行为可预测
+	paths := make([]string, 0, len(files))
+	var backupPaths []string
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if !isHistoryFile(path) {
+			backupPaths = append(backupPaths, path)
+		}
+	}
+
+	backup, err := stageBackup(anchor, backupPaths, p.backupCount)
+	if err != nil {
+		return err
+	}
+
+	tx := newFileTransaction()
+	for _, path := range paths {
+		if err := tx.Stage(path, files[path]); err != nil {
+			tx.Rollback()
+			backup.discard()
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		backup.discard()
+		return err
+	}
+
+	return backup.keep(p.backupCount)
 }
\ No newline at end of file
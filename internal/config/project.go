@@ -0,0 +1,120 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"configcraft/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectFileName 项目文件的默认文件名
+const ProjectFileName = "configcraft.project.yaml"
+
+// FormatConf 默认的输出格式
+const FormatConf = "conf"
+
+// IsProjectFile 根据文件名判断是否为项目文件（configcraft.project.yaml 或 *.project.yaml）
+func IsProjectFile(filePath string) bool {
+	name := strings.ToLower(filepath.Base(filePath))
+	return strings.HasSuffix(name, ".project.yaml") || strings.HasSuffix(name, ".project.yml")
+}
+
+// LoadProject 加载项目文件
+// 返回的项目中所有路径都已转换为绝对路径，未指定的schema和输出已按项目默认值补全
+func LoadProject(filePath string) (*models.Project, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project file: %w", err)
+	}
+
+	var project models.Project
+	if err := yaml.Unmarshal(data, &project); err != nil {
+		return nil, fmt.Errorf("failed to parse project file: %w", err)
+	}
+	if len(project.Configs) == 0 {
+		return nil, fmt.Errorf("project does not contain any configs")
+	}
+
+	absFile, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve project path: %w", err)
+	}
+	project.FilePath = absFile
+	baseDir := filepath.Dir(absFile)
+	resolve := func(path string) string {
+		if path == "" || filepath.IsAbs(path) {
+			return path
+		}
+		return filepath.Join(baseDir, filepath.FromSlash(path))
+	}
+
+	project.Schema = resolve(project.Schema)
+	names := make(map[string]bool)
+	for i := range project.Configs {
+		cfg := &project.Configs[i]
+		if cfg.Path == "" {
+			return nil, fmt.Errorf("config #%d has no path", i+1)
+		}
+		cfg.Path = resolve(cfg.Path)
+
+		if cfg.Name == "" {
+			cfg.Name = strings.TrimSuffix(filepath.Base(cfg.Path), filepath.Ext(cfg.Path))
+		}
+		if names[cfg.Name] {
+			return nil, fmt.Errorf("duplicate config name: %s", cfg.Name)
+		}
+		names[cfg.Name] = true
+
+		cfg.Schema = resolve(cfg.Schema)
+		if cfg.Schema == "" {
+			cfg.Schema = project.Schema
+		}
+		if cfg.Schema == "" {
+			return nil, fmt.Errorf("config %s has no schema", cfg.Name)
+		}
+
+		if len(cfg.Outputs) == 0 {
+			cfg.Outputs = []models.ProjectOutput{{Path: ConfPathFor(cfg.Path)}}
+		}
+		for j := range cfg.Outputs {
+			if cfg.Outputs[j].Path == "" {
+				return nil, fmt.Errorf("config %s: output #%d has no path", cfg.Name, j+1)
+			}
+			cfg.Outputs[j].Path = resolve(cfg.Outputs[j].Path)
+			cfg.Outputs[j].Template = resolve(cfg.Outputs[j].Template)
+		}
+	}
+
+	return &project, nil
+}
+
+// BuildProjectConfig 加载配置及其schema，生成该配置的所有输出文件
+// conf输出文件在上次生成后被手工修改过时返回*ConfModifiedError，force为true时直接覆盖
+func BuildProjectConfig(cfg models.ProjectConfig, force bool) ([]string, error) {
+	parser := NewParser()
+	parser.SetBackupCount(cfg.Generator.Backups)
+
+	if err := parser.LoadSchema(cfg.Schema); err != nil {
+		return nil, err
+	}
+	userConfig, err := parser.LoadUserConfig(cfg.Path)
+	if err != nil {
+		return nil, err
+	}
+	generate := parser.GenerateOutputs
+	if force {
+		generate = parser.OverwriteOutputs
+	}
+	if err := generate(userConfig, cfg.Outputs, cfg.Generator); err != nil {
+		return nil, err
+	}
+
+	outputs := make([]string, len(cfg.Outputs))
+	for i, output := range cfg.Outputs {
+		outputs[i] = output.Path
+	}
+	return outputs, nil
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"configcraft/internal/models"
+)
+
+func TestLoadProjectResolvesPaths(t *testing.T) {
+	dir := t.TempDir()
+	abs := filepath.Join(dir, "elsewhere", "right.h.tmpl")
+	projectPath := filepath.Join(dir, "fw", "configcraft.project.yaml")
+	writeFile(t, projectPath, `name: headset
+schema: schemas/dhf.yaml
+configs:
+  - path: configs/left.yaml
+  - name: right
+    path: configs/right.yaml
+    schema: ../other.yaml
+    generator:
+      mode: overrides
+    outputs:
+      - path: out/right.conf
+      - path: out/right.h
+        template: `+abs+`
+`)
+
+	project, err := LoadProject(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw := filepath.Join(dir, "fw")
+	if project.FilePath != projectPath || project.Schema != filepath.Join(fw, "schemas", "dhf.yaml") {
+		t.Errorf("project = %s, schema %s", project.FilePath, project.Schema)
+	}
+
+	want := []models.ProjectConfig{
+		{
+			Name:    "left",
+			Path:    filepath.Join(fw, "configs", "left.yaml"),
+			Schema:  filepath.Join(fw, "schemas", "dhf.yaml"),
+			Outputs: []models.ProjectOutput{{Path: filepath.Join(fw, "configs", "left.conf")}},
+		},
+		{
+			Name:      "right",
+			Path:      filepath.Join(fw, "configs", "right.yaml"),
+			Schema:    filepath.Join(dir, "other.yaml"),
+			Generator: models.GeneratorSettings{Mode: OutputOverrides},
+			Outputs: []models.ProjectOutput{
+				{Path: filepath.Join(fw, "out", "right.conf")},
+				{Path: filepath.Join(fw, "out", "right.h"), Template: abs},
+			},
+		},
+	}
+	if !reflect.DeepEqual(project.Configs, want) {
+		t.Errorf("configs = %+v\nwant %+v", project.Configs, want)
+	}
+}
+
+func TestLoadProjectInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string // 为空时项目文件不存在
+		err     string
+	}{
+		{"missing file", "", "failed to read project file"},
+		{"invalid yaml", "configs: [", "failed to parse project file"},
+		{"no configs", "name: empty\nschema: s.yaml\n", "does not contain any configs"},
+		{"config without path", "schema: s.yaml\nconfigs:\n  - name: left\n", "config #1 has no path"},
+		{"duplicate name", "schema: s.yaml\nconfigs:\n  - path: a/left.yaml\n  - path: b/left.yaml\n", "duplicate config name: left"},
+		{"no schema", "configs:\n  - path: left.yaml\n", "config left has no schema"},
+		{"output without path", "schema: s.yaml\nconfigs:\n  - path: left.yaml\n    outputs:\n      - format: conf\n", "output #1 has no path"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectPath := filepath.Join(t.TempDir(), ProjectFileName)
+			if tt.content != "" {
+				writeFile(t, projectPath, tt.content)
+			}
+			project, err := LoadProject(projectPath)
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("LoadProject = %v, %v; want error containing %q", project, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestIsProjectFile(t *testing.T) {
+	tests := map[string]bool{
+		"configcraft.project.yaml":     true,
+		"dir/Headset.PROJECT.yml":      true,
+		"project.yaml":                 false,
+		"left.yaml":                    false,
+		"configcraft.project.yaml.bak": false,
+	}
+	for path, want := range tests {
+		if got := IsProjectFile(path); got != want {
+			t.Errorf("IsProjectFile(%s) = %v, want %v", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package config
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// 批量处理报告的格式
+const (
+	ReportText  = "text"
+	ReportJSON  = "json"
+	ReportJUnit = "junit"
+)
+
+// ReportFormats 支持的报告格式
+var ReportFormats = []string{ReportText, ReportJSON, ReportJUnit}
+
+// WriteBatchReport 按指定格式输出批量处理的汇总报告
+func WriteBatchReport(w io.Writer, format, schemaPath string, results []BatchResult) error {
+	switch format {
+	case "", ReportText:
+		return writeTextReport(w, results)
+	case ReportJSON:
+		return writeJSONReport(w, schemaPath, results)
+	case ReportJUnit:
+		return writeJUnitReport(w, schemaPath, results)
+	}
+	return fmt.Errorf("unsupported report format: %s", format)
+}
+
+// writeTextReport 表格形式的报告，每个问题单独一行列在对应配置下
+func writeTextReport(w io.Writer, results []BatchResult) error {
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(table, "STATUS\tCONFIG\tOUTPUT\tERRORS\tWARNINGS")
+	for _, result := range results {
+		errors, warnings := countIssues(result)
+		output := result.OutputPath
+		if output == "" {
+			output = "-"
+		}
+		fmt.Fprintf(table, "%s\t%s\t%s\t%d\t%d\n", strings.ToUpper(result.Status()), result.ConfigPath, output, errors, warnings)
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if result.Err == nil && len(result.Issues) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s\n", result.ConfigPath)
+		if result.Err != nil {
+			fmt.Fprintf(w, "  error: %v\n", result.Err)
+		}
+		for _, issue := range result.Issues {
+			fmt.Fprintf(w, "  %s: %s\n", issue.Severity, issue)
+		}
+	}
+
+	summary := SummarizeBatch(results)
+	_, err := fmt.Fprintf(w, "\n%d configs: %d passed, %d with warnings, %d failed\n",
+		summary.Total, summary.Passed, summary.Warnings, summary.Failed)
+	return err
+}
+
+// countIssues 统计错误和警告数，加载/生成失败计为一个错误
+func countIssues(result BatchResult) (errors, warnings int) {
+	if result.Err != nil {
+		errors++
+	}
+	for _, issue := range result.Issues {
+		if issue.Severity == SeverityError {
+			errors++
+		} else {
+			warnings++
+		}
+	}
+	return errors, warnings
+}
+
+type jsonReport struct {
+	Schema  string       `json:"schema"`
+	Summary BatchSummary `json:"summary"`
+	Results []jsonResult `json:"results"`
+}
+
+type jsonResult struct {
+	Config     string  `json:"config"`
+	Status     string  `json:"status"`
+	Output     string  `json:"output,omitempty"`
+	Error      string  `json:"error,omitempty"`
+	Issues     []Issue `json:"issues"`
+	DurationMs int64   `json:"duration_ms"`
+}
+
+func writeJSONReport(w io.Writer, schemaPath string, results []BatchResult) error {
+	report := jsonReport{Schema: schemaPath, Summary: SummarizeBatch(results), Results: []jsonResult{}}
+	for _, result := range results {
+		item := jsonResult{
+			Config:     result.ConfigPath,
+			Status:     result.Status(),
+			Output:     result.OutputPath,
+			Issues:     result.Issues,
+			DurationMs: result.Duration.Milliseconds(),
+		}
+		if item.Issues == nil {
+			item.Issues = []Issue{}
+		}
+		if result.Err != nil {
+			item.Error = result.Err.Error()
+		}
+		report.Results = append(report.Results, item)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnitReport 每个配置对应一个testcase；失败的配置带failure，警告写入system-out
+func writeJUnitReport(w io.Writer, schemaPath string, results []BatchResult) error {
+	suite := junitSuite{Name: "configcraft: " + schemaPath, Tests: len(results)}
+	var total float64
+	for _, result := range results {
+		testCase := junitTestCase{
+			Name:      result.ConfigPath,
+			Classname: "configcraft.batch",
+			Time:      fmt.Sprintf("%.3f", result.Duration.Seconds()),
+		}
+		total += result.Duration.Seconds()
+
+		var errors, warnings []string
+		if result.Err != nil {
+			errors = append(errors, result.Err.Error())
+		}
+		for _, issue := range result.Issues {
+			if issue.Severity == SeverityError {
+				errors = append(errors, issue.String())
+			} else {
+				warnings = append(warnings, "warning: "+issue.String())
+			}
+		}
+
+		if len(errors) > 0 {
+			suite.Failures++
+			testCase.Failure = &junitFailure{
+				Message: fmt.Sprintf("%d error(s)", len(errors)),
+				Text:    strings.Join(errors, "\n"),
+			}
+		}
+		testCase.SystemOut = strings.Join(warnings, "\n")
+		suite.Cases = append(suite.Cases, testCase)
+	}
+	suite.Time = fmt.Sprintf("%.3f", total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(junitTestSuites{Suites: []junitSuite{suite}}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"sort"
+	"strings"
+
+	"configcraft/internal/models"
+)
+
+// ForEachField 按固定顺序遍历schema中的所有字段
+// path为配置项的完整路径：section.field 或 section.group.field
+func ForEachField(schema *models.Schema, fn func(path string, field models.ConfigField)) {
+	if schema == nil {
+		return
+	}
+
+	for _, sectionKey := range sortedKeys(schema.Sections) {
+		section := schema.Sections[sectionKey]
+		for _, fieldKey := range sortedKeys(section.Fields) {
+			fn(sectionKey+"."+fieldKey, section.Fields[fieldKey])
+		}
+		for _, groupKey := range sortedKeys(section.Groups) {
+			group := section.Groups[groupKey]
+			for _, fieldKey := range sortedKeys(group.Fields) {
+				fn(sectionKey+"."+groupKey+"."+fieldKey, group.Fields[fieldKey])
+			}
+		}
+	}
+}
+
+// LookupField 按完整路径查找schema字段
+func LookupField(schema *models.Schema, path string) (models.ConfigField, bool) {
+	if schema == nil {
+		return models.ConfigField{}, false
+	}
+
+	parts := strings.SplitN(path, ".", 3)
+	section, exists := schema.Sections[parts[0]]
+	if !exists || len(parts) < 2 {
+		return models.ConfigField{}, false
+	}
+
+	if len(parts) == 3 {
+		if group, exists := section.Groups[parts[1]]; exists {
+			if field, exists := group.Fields[parts[2]]; exists {
+				return field, true
+			}
+		}
+	}
+
+	field, exists := section.Fields[strings.Join(parts[1:], ".")]
+	return field, exists
+}
+
+// sortedKeys 返回map的有序键列表
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
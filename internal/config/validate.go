@@ -0,0 +1,112 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	"configcraft/internal/models"
+)
+
+// 校验问题的严重程度
+const (
+	SeverityError   = "error"   // 生成的输出不可用
+	SeverityWarning = "warning" // 可以生成，但值得检查
+)
+
+// Issue 配置中的一个校验问题
+type Issue struct {
+	Path     string `json:"path"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s", i.Path, i.Message)
+}
+
+// ValidateConfig 按schema检查用户配置，结果按路径排序
+// 缺少必填值、类型不符、超出范围、不在select或flags选项中、无法写入输出的map项为错误；
+// schema中不存在的配置项、不在combo预设选项中的值、扩展名不符的路径为警告
+func ValidateConfig(schema *models.Schema, config *models.UserConfig) []Issue {
+	var issues []Issue
+	add := func(path, severity, format string, args ...interface{}) {
+		issues = append(issues, Issue{Path: path, Severity: severity, Message: fmt.Sprintf(format, args...)})
+	}
+
+	known := make(map[string]bool)
+	ForEachField(schema, func(path string, field models.ConfigField) {
+		known[path] = true
+
+		value, exists := config.Values[path]
+		if !exists || value == nil {
+			if field.Required && field.Default == nil {
+				add(path, SeverityError, "required value is missing")
+			}
+			return
+		}
+
+		switch field.Type {
+		case "number":
+			n, ok := value.(int)
+			if !ok {
+				add(path, SeverityError, "expected a number, got %v", value)
+				return
+			}
+			if field.Min != nil && n < *field.Min {
+				add(path, SeverityError, "%d is less than the minimum %d", n, *field.Min)
+			}
+			if field.Max != nil && n > *field.Max {
+				add(path, SeverityError, "%d is greater than the maximum %d", n, *field.Max)
+			}
+		case "boolean":
+			if _, ok := value.(bool); !ok {
+				add(path, SeverityError, "expected true or false, got %v", value)
+			}
+		case "select":
+			if !hasOption(field, value) {
+				add(path, SeverityError, "%v is not one of the allowed options", value)
+			}
+		case "combo":
+			if len(field.Options) > 0 && !hasOption(field, value) {
+				add(path, SeverityWarning, "%v is not one of the preset options", value)
+			}
+		default:
+			validateFieldValue(field, value, func(severity, format string, args ...interface{}) {
+				add(path, severity, format, args...)
+			})
+		}
+	})
+
+	if schema != nil {
+		for path := range config.Values {
+			if !known[path] {
+				add(path, SeverityWarning, "not defined in schema")
+			}
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Path < issues[j].Path
+	})
+	return issues
+}
+
+// HasErrors 是否存在错误级别的问题
+func HasErrors(issues []Issue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// hasOption 值是否为字段的选项之一（YAML中数字和字符串形式的选项值视为相同）
+func hasOption(field models.ConfigField, value interface{}) bool {
+	for _, option := range field.Options {
+		if fmt.Sprintf("%v", option.Value) == fmt.Sprintf("%v", value) {
+			return true
+		}
+	}
+	return false
+}
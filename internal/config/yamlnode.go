@@ -0,0 +1,217 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"configcraft/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// 用户配置文件中的顶层键
+const (
+	valuesKey = "values" // 配置项的值
+	notesKey  = "notes"  // 配置项的备注
+)
+
+// defaultIndent 无法从原文件推断缩进时使用的缩进宽度
+const defaultIndent = 4
+
+// patchUserConfig 将config.Values和config.Notes写回加载时的YAML文档树并重新序列化
+// 已有键只修改值节点，注释、键顺序、引号风格和未知的顶层键都保持不变；
+// 新增的键按字母顺序追加在values（notes）末尾，已删除的键从文档中移除，没有备注时移除notes
+func patchUserConfig(config *models.UserConfig) ([]byte, error) {
+	doc := config.Source
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		doc.Kind = yaml.DocumentNode
+		doc.Content = []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("config root is not a mapping")
+	}
+
+	values := mappingChild(root, valuesKey)
+	indent := detectIndent(values)
+	if err := patchMapping(values, config.Values, setNodeValue); err != nil {
+		return nil, err
+	}
+
+	if len(config.Notes) == 0 {
+		removeKey(root, notesKey)
+	} else {
+		notes := make(map[string]interface{}, len(config.Notes))
+		for path, note := range config.Notes {
+			notes[path] = note
+		}
+		if err := patchMapping(mappingChild(root, notesKey), notes, setNoteNode); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(indent)
+	if err := encoder.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// mappingChild 返回映射节点中键对应的映射，不存在或不是映射时创建（替换）为空映射
+func mappingChild(mapping *yaml.Node, key string) *yaml.Node {
+	child := mappingValue(mapping, key)
+	if child != nil && child.Kind == yaml.MappingNode {
+		return child
+	}
+	if child == nil {
+		child = &yaml.Node{}
+		mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, child)
+	}
+	child.Kind = yaml.MappingNode
+	child.Tag = "!!map"
+	child.Style = 0
+	child.Value = ""
+	child.Content = nil
+	return child
+}
+
+// removeKey 从映射节点中移除键
+func removeKey(mapping *yaml.Node, key string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+// patchMapping 用entries更新映射节点：已有键用set修改值节点，不在entries中的键被移除，
+// 新键按字母顺序追加在末尾
+func patchMapping(mapping *yaml.Node, entries map[string]interface{}, set func(*yaml.Node, interface{}) error) error {
+	// 更新已有键，移除已删除的键
+	seen := make(map[string]bool)
+	content := mapping.Content[:0]
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		keyNode, valueNode := mapping.Content[i], mapping.Content[i+1]
+		value, exists := entries[keyNode.Value]
+		if !exists {
+			continue
+		}
+		if err := set(valueNode, value); err != nil {
+			return fmt.Errorf("failed to update %s: %w", keyNode.Value, err)
+		}
+		seen[keyNode.Value] = true
+		content = append(content, keyNode, valueNode)
+	}
+	mapping.Content = content
+
+	// 追加新键
+	var newKeys []string
+	for key := range entries {
+		if !seen[key] {
+			newKeys = append(newKeys, key)
+		}
+	}
+	sort.Strings(newKeys)
+	for _, key := range newKeys {
+		valueNode := &yaml.Node{}
+		if err := valueNode.Encode(entries[key]); err != nil {
+			return fmt.Errorf("failed to encode %s: %w", key, err)
+		}
+		mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, valueNode)
+	}
+	return nil
+}
+
+// mappingValue 在映射节点中查找键对应的值节点
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// detectIndent 根据values下第一个键所在的列推断原文件的缩进宽度
+func detectIndent(values *yaml.Node) int {
+	if len(values.Content) > 0 && values.Content[0].Column > 2 && values.Style&yaml.FlowStyle == 0 {
+		return values.Content[0].Column - 1
+	}
+	return defaultIndent
+}
+
+// setNodeValue 用新值更新节点，值未变化时不做任何修改以保留原始写法（如0x10、'abc'）
+func setNodeValue(node *yaml.Node, value interface{}) error {
+	var current interface{}
+	if err := node.Decode(&current); err == nil && reflect.DeepEqual(current, value) {
+		return nil
+	}
+
+	var fresh yaml.Node
+	if err := fresh.Encode(value); err != nil {
+		return err
+	}
+
+	if fresh.Kind == yaml.ScalarNode && node.Kind == yaml.ScalarNode {
+		style := node.Style
+		if fresh.Tag != "!!str" || (fresh.Style != 0 && style == 0) {
+			// 非字符串不加引号；需要引号的字符串（如"true"）使用编码器选择的风格
+			style = fresh.Style
+		}
+		node.Tag = fresh.Tag
+		node.Value = fresh.Value
+		node.Style = style
+		return nil
+	}
+
+	replaceNode(node, &fresh)
+	return nil
+}
+
+// setNoteNode 用models.FieldNote更新备注节点，逐项修改note、author和date，保留其余写法
+func setNoteNode(node *yaml.Node, value interface{}) error {
+	note := value.(models.FieldNote)
+	if node.Kind != yaml.MappingNode {
+		var fresh yaml.Node
+		if err := fresh.Encode(note); err != nil {
+			return err
+		}
+		replaceNode(node, &fresh)
+		return nil
+	}
+
+	fields := map[string]interface{}{"note": note.Note}
+	if note.Author != "" {
+		fields["author"] = note.Author
+	}
+	if note.Date != "" {
+		fields["date"] = note.Date
+	}
+	return patchMapping(node, fields, setTextNode)
+}
+
+// setTextNode 按文字比较后更新字符串节点，未加引号的日期等不会因类型不同而被改写
+func setTextNode(node *yaml.Node, value interface{}) error {
+	var current string
+	if err := node.Decode(&current); err == nil && current == value {
+		return nil
+	}
+	return setNodeValue(node, value)
+}
+
+// replaceNode 类型结构发生变化（如标量变为列表）时替换节点内容，保留注释
+func replaceNode(node, fresh *yaml.Node) {
+	fresh.HeadComment = node.HeadComment
+	fresh.LineComment = node.LineComment
+	fresh.FootComment = node.FootComment
+	*node = *fresh
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"configcraft/internal/models"
+)
+
+// commentedConfig 带注释、自定义顺序、引号和未知顶层键的配置文件
+const commentedConfig = `# 左耳配置
+owner: audio-team # 工具不认识的键
+values:
+    # 音量
+    basic.volume: 8 # 出厂值
+    basic.name: "left"
+    basic.old: 1
+`
+
+func TestPatchUserConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(config *models.UserConfig)
+		want string
+	}{
+		{
+			name: "unchanged",
+			edit: func(config *models.UserConfig) {},
+			want: commentedConfig,
+		},
+		{
+			name: "changed value keeps comments",
+			edit: func(config *models.UserConfig) {
+				config.Values["basic.volume"] = 10
+				config.Values["basic.name"] = "right"
+			},
+			want: `# 左耳配置
+owner: audio-team # 工具不认识的键
+values:
+    # 音量
+    basic.volume: 10 # 出厂值
+    basic.name: "right"
+    basic.old: 1
+`,
+		},
+		{
+			name: "added and removed keys",
+			edit: func(config *models.UserConfig) {
+				delete(config.Values, "basic.old")
+				config.Values["basic.mode"] = "eco"
+				config.Values["basic.bands"] = []interface{}{1, 2}
+			},
+			want: `# 左耳配置
+owner: audio-team # 工具不认识的键
+values:
+    # 音量
+    basic.volume: 8 # 出厂值
+    basic.name: "left"
+    basic.bands:
+        - 1
+        - 2
+    basic.mode: eco
+`,
+		},
+		{
+			name: "notes",
+			edit: func(config *models.UserConfig) {
+				SetFieldNote(config, "basic.volume", models.FieldNote{Note: "客户要求"})
+			},
+			want: commentedConfig + `notes:
+    basic.volume:
+        note: 客户要求
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "cfg.yaml")
+			writeFile(t, path, commentedConfig)
+
+			p := NewParser()
+			config, err := p.LoadUserConfig(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.edit(config)
+
+			data, err := p.renderUserConfig(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestPatchUserConfigKeepsIndent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cfg.yaml")
+	writeFile(t, path, "values:\n  basic.volume: 8\n")
+
+	p := NewParser()
+	config, err := p.LoadUserConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.Values["basic.keys"] = map[string]interface{}{"a": 1}
+
+	data, err := p.renderUserConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "values:\n  basic.volume: 8\n  basic.keys:\n    a: 1\n"; string(data) != want {
+		t.Errorf("got:\n%s\nwant:\n%s", data, want)
+	}
+}
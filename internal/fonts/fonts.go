@@ -0,0 +1,363 @@
+// Package fonts 查找能显示中文的界面字体
+// 依次查找：随程序打包的字体、用户指定的字体文件、系统字体目录中常见的CJK字体
+package fonts
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"github.com/go-text/typesetting/font"
+)
+
+// bundled 随程序打包的字体，把regular/bold/monospace.ttf（或.otf、.ttc）放入bundled目录后重新构建即可
+//
+//go:embed bundled
+var bundled embed.FS
+
+// Style 字体样式
+type Style int
+
+const (
+	Regular Style = iota
+	Bold
+	Monospace
+)
+
+var styleNames = map[Style]string{
+	Regular:   "regular",
+	Bold:      "bold",
+	Monospace: "monospace",
+}
+
+func (s Style) String() string {
+	return styleNames[s]
+}
+
+// 用户指定字体文件的环境变量，优先于偏好设置中的路径
+const (
+	EnvFont          = "CONFIGCRAFT_FONT"
+	EnvFontBold      = "CONFIGCRAFT_FONT_BOLD"
+	EnvFontMonospace = "CONFIGCRAFT_FONT_MONOSPACE"
+)
+
+// Font 找到的一个字体
+type Font struct {
+	Resource fyne.Resource
+	Family   string // 字体名称，如"Noto Sans CJK SC"
+	Source   string // 来源：文件路径，打包的字体为"bundled/文件名"
+}
+
+// Set 界面使用的各样式字体
+// 粗体和等宽字体与常规字体来自同一来源，该来源中没有对应样式时使用常规字体
+type Set struct {
+	Regular   *Font // 为nil表示没有找到能显示中文的字体
+	Bold      *Font
+	Monospace *Font
+}
+
+// Found 是否找到了能显示中文的字体
+func (s *Set) Found() bool {
+	return s != nil && s.Regular != nil
+}
+
+// For 返回文字样式对应的字体，没有找到时返回nil（使用Fyne的默认字体）
+// 中文字体通常没有斜体，斜体使用同一字体
+func (s *Set) For(style fyne.TextStyle) *Font {
+	switch {
+	case !s.Found() || style.Symbol:
+		return nil
+	case style.Monospace:
+		return s.Monospace
+	case style.Bold:
+		return s.Bold
+	default:
+		return s.Regular
+	}
+}
+
+// Options 用户指定的字体文件，为空表示未指定
+type Options struct {
+	Path          string
+	BoldPath      string
+	MonospacePath string
+}
+
+// OptionsFromEnv 在已有设置的基础上应用环境变量中指定的字体文件
+func OptionsFromEnv(opts Options) Options {
+	for env, target := range map[string]*string{
+		EnvFont:          &opts.Path,
+		EnvFontBold:      &opts.BoldPath,
+		EnvFontMonospace: &opts.MonospacePath,
+	} {
+		if value := os.Getenv(env); value != "" {
+			*target = value
+		}
+	}
+	return opts
+}
+
+// source 字体的一个来源，按样式查找
+type source struct {
+	name string
+	find func(style Style) *Font
+}
+
+// Resolve 依次在打包的字体、用户指定的文件、系统字体目录中查找能显示中文的字体
+// 第一个找到常规字体的来源决定整套字体，避免粗体和常规字体来自不同的字体家族
+func Resolve(opts Options) *Set {
+	r := &resolver{}
+	sources := []source{
+		{"bundled", r.findBundled},
+		{"user", func(style Style) *Font { return r.findUser(opts, style) }},
+		{"system", r.findSystem},
+	}
+
+	for _, src := range sources {
+		regular := src.find(Regular)
+		if regular == nil {
+			continue
+		}
+		set := &Set{Regular: regular, Bold: src.find(Bold), Monospace: src.find(Monospace)}
+		if set.Bold == nil {
+			set.Bold = regular
+		}
+		if set.Monospace == nil {
+			set.Monospace = regular
+		}
+		log.Printf("Using %s fonts: regular %q (%s), bold %q (%s), monospace %q (%s)", src.name,
+			set.Regular.Family, set.Regular.Source, set.Bold.Family, set.Bold.Source,
+			set.Monospace.Family, set.Monospace.Source)
+		return set
+	}
+	return &Set{}
+}
+
+// resolver 一次查找过程
+type resolver struct {
+	systemIndex map[string]string // 系统字体目录中的文件：小写文件名 -> 路径
+}
+
+// findBundled 在打包的字体中查找，文件名为样式名加扩展名
+func (r *resolver) findBundled(style Style) *Font {
+	for _, ext := range fontExtensions {
+		name := path.Join("bundled", style.String()+ext)
+		data, err := fs.ReadFile(bundled, name)
+		if err != nil {
+			continue
+		}
+		if f := selectFace(sfntFile{bytes.NewReader(data), int64(len(data))}, style, false); f != nil {
+			f.Source = name
+			return f
+		}
+		log.Printf("Warning: bundled font %s cannot display Chinese text, ignored", name)
+	}
+	return nil
+}
+
+// findUser 使用用户指定的字体文件，用户指定的等宽字体不检查名称
+func (r *resolver) findUser(opts Options, style Style) *Font {
+	filePath := map[Style]string{Regular: opts.Path, Bold: opts.BoldPath, Monospace: opts.MonospacePath}[style]
+	if filePath == "" {
+		return nil
+	}
+	f, err := r.loadFile(filePath, style, true)
+	if err != nil {
+		log.Printf("Warning: configured %s font %s ignored: %v", style, filePath, err)
+		return nil
+	}
+	return f
+}
+
+// findSystem 在系统字体目录中按候选列表的顺序查找
+func (r *resolver) findSystem(style Style) *Font {
+	if r.systemIndex == nil {
+		r.systemIndex = indexFontDirs(systemFontDirs())
+	}
+	for _, name := range candidates[style] {
+		filePath, exists := r.systemIndex[strings.ToLower(name)]
+		if !exists {
+			continue
+		}
+		if f, err := r.loadFile(filePath, style, false); err == nil {
+			return f
+		}
+	}
+	return nil
+}
+
+// loadFile 从字体文件中选出合适的字体，只读取选中的字体，不把整个字体集合读入内存
+func (r *resolver) loadFile(filePath string, style Style, userChosen bool) (*Font, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	f := selectFace(sfntFile{file, info.Size()}, style, userChosen)
+	if f == nil {
+		return nil, fmt.Errorf("no %s face that can display Chinese text", style)
+	}
+	f.Source = filePath
+	return f, nil
+}
+
+// selectFace 从字体文件（集合中的每个字体）中选出最适合该样式、能显示中文的字体
+// 优先简体中文字体（名称中含SC或GB）；粗体要求subfamily为粗体；
+// 等宽字体要求名称中含Mono，常规和粗体则排除等宽字体（userChosen时不检查名称）。
+// 先只读name表按名称给各字体排序，再按顺序取出字体检查能否显示中文，通常只取出一个字体
+func selectFace(file sfntFile, style Style, userChosen bool) *Font {
+	count, err := file.faceCount()
+	if err != nil {
+		return nil
+	}
+
+	type candidate struct {
+		index  int
+		family string
+		score  int
+	}
+	var faces []candidate
+	for index := 0; index < count; index++ {
+		family, subfamily := file.faceNames(index)
+		mono := strings.Contains(family, "Mono")
+		bold := strings.Contains(strings.ToLower(subfamily), "bold")
+		if !userChosen && (mono != (style == Monospace) || (style == Bold && !bold)) {
+			continue
+		}
+
+		score := 0
+		if strings.Contains(family, "SC") || strings.Contains(family, "GB") {
+			score += 2
+		}
+		if style != Bold && !bold {
+			score++
+		}
+		faces = append(faces, candidate{index, family, score})
+	}
+	// 同分时保持集合中的顺序
+	sort.SliceStable(faces, func(i, j int) bool { return faces[i].score > faces[j].score })
+
+	for _, c := range faces {
+		face, err := file.extractFont(c.index)
+		if err != nil || !canDisplayChinese(face) {
+			continue
+		}
+		name := c.family
+		if name == "" {
+			name = fmt.Sprintf("font #%d", c.index)
+		}
+		return &Font{Resource: fyne.NewStaticResource(name, face), Family: name}
+	}
+	return nil
+}
+
+// canDisplayChinese 字体能否被Fyne加载并包含常用汉字
+func canDisplayChinese(data []byte) bool {
+	face, err := font.ParseTTF(bytes.NewReader(data))
+	if err != nil {
+		return false
+	}
+	for _, r := range "中文配置" {
+		if _, ok := face.NominalGlyph(r); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// fontExtensions Fyne能加载的字体格式（.ttc会被拆分为单个字体）
+var fontExtensions = []string{".ttf", ".otf", ".ttc"}
+
+// candidates 系统中常见的CJK字体文件，按优先顺序
+var candidates = map[Style][]string{
+	Regular: {
+		// Windows
+		"msyh.ttc", "msyh.ttf", "Deng.ttf", "simhei.ttf", "simsun.ttc",
+		// macOS
+		"PingFang.ttc", "Hiragino Sans GB.ttc", "STHeiti Light.ttc", "Songti.ttc", "Arial Unicode.ttf",
+		// Linux
+		"NotoSansCJK-Regular.ttc", "NotoSansCJKsc-Regular.otf", "NotoSansSC-Regular.otf",
+		"SourceHanSansSC-Regular.otf", "SourceHanSans-Regular.ttc",
+		"wqy-microhei.ttc", "wqy-zenhei.ttc", "DroidSansFallbackFull.ttf", "DroidSansFallback.ttf",
+		"uming.ttc", "ukai.ttc",
+	},
+	Bold: {
+		"msyhbd.ttc", "msyhbd.ttf", "Dengb.ttf",
+		"PingFang.ttc",
+		"NotoSansCJK-Bold.ttc", "NotoSansCJKsc-Bold.otf", "NotoSansSC-Bold.otf",
+		"SourceHanSansSC-Bold.otf", "SourceHanSans-Bold.ttc",
+	},
+	Monospace: {
+		"SarasaMonoSC-Regular.ttf", "sarasa-mono-sc-regular.ttf",
+		"NotoSansMonoCJKsc-Regular.otf", "NotoSansCJK-Regular.ttc",
+		"wqy-microhei.ttc", "wqy-zenhei.ttc",
+	},
+}
+
+// systemFontDirs 当前平台的字体目录
+func systemFontDirs() []string {
+	home, _ := os.UserHomeDir()
+	switch runtime.GOOS {
+	case "windows":
+		windir := os.Getenv("WINDIR")
+		if windir == "" {
+			windir = `C:\Windows`
+		}
+		return []string{
+			filepath.Join(windir, "Fonts"),
+			filepath.Join(os.Getenv("LOCALAPPDATA"), "Microsoft", "Windows", "Fonts"),
+		}
+	case "darwin":
+		return []string{
+			"/System/Library/Fonts",
+			"/System/Library/Fonts/Supplemental",
+			"/Library/Fonts",
+			filepath.Join(home, "Library", "Fonts"),
+		}
+	default:
+		dirs := []string{filepath.Join(home, ".local", "share", "fonts"), filepath.Join(home, ".fonts")}
+		dataDirs := os.Getenv("XDG_DATA_DIRS")
+		if dataDirs == "" {
+			dataDirs = "/usr/local/share:/usr/share"
+		}
+		for _, dir := range filepath.SplitList(dataDirs) {
+			dirs = append(dirs, filepath.Join(dir, "fonts"))
+		}
+		return dirs
+	}
+}
+
+// indexFontDirs 递归列出目录中的字体文件，同名文件以先找到的为准
+func indexFontDirs(dirs []string) map[string]string {
+	index := make(map[string]string)
+	for _, dir := range dirs {
+		filepath.WalkDir(dir, func(filePath string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				if entry != nil && entry.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			name := strings.ToLower(entry.Name())
+			if _, exists := index[name]; !exists && !entry.IsDir() {
+				index[name] = filePath
+			}
+			return nil
+		})
+	}
+	return index
+}
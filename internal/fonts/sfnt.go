@@ -0,0 +1,242 @@
+package fonts
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf16"
+)
+
+// 只实现选择字体所需的最少的sfnt（TrueType/OpenType）解析：
+// 拆分字体集合(.ttc)，读取字体的family和subfamily名称
+// 字体集合可能有几十MB，这里只按需读取表目录、name表和最终选中的字体
+
+const (
+	sfntHeaderSize = 12 // sfnt版本 + 表数量 + 3个二分查找参数
+	sfntRecordSize = 16 // 表标签 + 校验和 + 偏移 + 长度
+	ttcHeaderSize  = 12 // "ttcf" + 版本 + 字体数量，之后是各字体的偏移
+)
+
+var errBadFont = errors.New("not a TrueType/OpenType font")
+
+// sfntFile 一个字体文件或字体集合
+type sfntFile struct {
+	r    io.ReaderAt
+	size int64
+}
+
+// read 读取从offset开始的n个字节，超出文件范围时返回errBadFont
+func (f sfntFile) read(offset, n int64) ([]byte, error) {
+	if offset < 0 || n < 0 || offset+n > f.size {
+		return nil, errBadFont
+	}
+	buf := make([]byte, n)
+	if read, err := f.r.ReadAt(buf, offset); read < len(buf) {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// isCollection 文件是否是字体集合(.ttc)
+func (f sfntFile) isCollection() bool {
+	tag, err := f.read(0, 4)
+	return err == nil && f.size >= ttcHeaderSize && string(tag) == "ttcf"
+}
+
+// faceCount 字体集合中的字体数量，单个字体返回1
+func (f sfntFile) faceCount() (int, error) {
+	if !f.isCollection() {
+		return 1, nil
+	}
+	header, err := f.read(8, 4)
+	if err != nil {
+		return 0, err
+	}
+	count := int64(binary.BigEndian.Uint32(header))
+	// 偏移表必须完整，避免损坏的文件声明过多的字体
+	if ttcHeaderSize+4*count > f.size {
+		return 0, errBadFont
+	}
+	return int(count), nil
+}
+
+// faceOffset 第index个字体的表目录在文件中的位置
+func (f sfntFile) faceOffset(index int) (int64, error) {
+	count, err := f.faceCount()
+	if err != nil {
+		return 0, err
+	}
+	if index < 0 || index >= count {
+		return 0, fmt.Errorf("font index %d out of range", index)
+	}
+	if !f.isCollection() {
+		return 0, nil
+	}
+	entry, err := f.read(ttcHeaderSize+4*int64(index), 4)
+	if err != nil {
+		return 0, err
+	}
+	return int64(binary.BigEndian.Uint32(entry)), nil
+}
+
+// tableRecord sfnt表目录中的一项
+type tableRecord struct {
+	tag    string
+	offset uint32
+	length uint32
+}
+
+// tableRecords 读取从start开始的sfnt表目录，偏移相对于整个文件
+func (f sfntFile) tableRecords(start int64) ([]tableRecord, error) {
+	header, err := f.read(start, sfntHeaderSize)
+	if err != nil {
+		return nil, err
+	}
+	count := int64(binary.BigEndian.Uint16(header[4:]))
+	directory, err := f.read(start+sfntHeaderSize, sfntRecordSize*count)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]tableRecord, count)
+	for i := range records {
+		entry := directory[sfntRecordSize*i:]
+		records[i] = tableRecord{
+			tag:    string(entry[:4]),
+			offset: binary.BigEndian.Uint32(entry[8:]),
+			length: binary.BigEndian.Uint32(entry[12:]),
+		}
+		if int64(records[i].offset)+int64(records[i].length) > f.size {
+			return nil, errBadFont
+		}
+	}
+	return records, nil
+}
+
+// extractFont 取出第index个字体，集合中的字体重新排列为独立的字体文件
+// Fyne只能加载单个字体，而Linux和macOS上的CJK字体大多以集合形式提供
+func (f sfntFile) extractFont(index int) ([]byte, error) {
+	start, err := f.faceOffset(index)
+	if err != nil {
+		return nil, err
+	}
+	if !f.isCollection() {
+		// 单个字体原样返回，先确认表目录完整
+		if _, err := f.tableRecords(0); err != nil {
+			return nil, err
+		}
+		return f.read(0, f.size)
+	}
+
+	records, err := f.tableRecords(start)
+	if err != nil {
+		return nil, err
+	}
+	directory, err := f.read(start, sfntHeaderSize+sfntRecordSize*int64(len(records)))
+	if err != nil {
+		return nil, err
+	}
+
+	headerSize := len(directory)
+	size := headerSize
+	for _, record := range records {
+		size += pad4(int(record.length))
+	}
+
+	out := make([]byte, size)
+	copy(out, directory)
+	offset := headerSize
+	for i, record := range records {
+		binary.BigEndian.PutUint32(out[sfntHeaderSize+sfntRecordSize*i+8:], uint32(offset))
+		table := out[offset : offset+int(record.length)]
+		if read, err := f.r.ReadAt(table, int64(record.offset)); read < len(table) {
+			return nil, err
+		}
+		offset += pad4(int(record.length))
+	}
+	return out, nil
+}
+
+// faceNames 读取第index个字体的family和subfamily名称（如"Noto Sans CJK SC"和"Bold"），读取失败时为空
+// 只读取表目录和name表，不取出整个字体
+func (f sfntFile) faceNames(index int) (family, subfamily string) {
+	start, err := f.faceOffset(index)
+	if err != nil {
+		return "", ""
+	}
+	records, err := f.tableRecords(start)
+	if err != nil {
+		return "", ""
+	}
+	for _, record := range records {
+		if record.tag == "name" {
+			table, err := f.read(int64(record.offset), int64(record.length))
+			if err != nil {
+				return "", ""
+			}
+			return nameTableString(table, 1, 16), nameTableString(table, 2, 17)
+		}
+	}
+	return "", ""
+}
+
+// nameTableString 从name表中选出一个名称：优先typographic名称(typographicID)，
+// 其次基本名称(basicID)；优先Windows平台的美式英语
+func nameTableString(table []byte, basicID, typographicID uint16) string {
+	if len(table) < 6 {
+		return ""
+	}
+	count := int(binary.BigEndian.Uint16(table[2:]))
+	storage := int(binary.BigEndian.Uint16(table[4:]))
+
+	best, bestScore := "", 0
+	for i := 0; i < count; i++ {
+		if len(table) < 6+12*(i+1) {
+			break
+		}
+		entry := table[6+12*i:]
+		platform := binary.BigEndian.Uint16(entry[0:])
+		language := binary.BigEndian.Uint16(entry[4:])
+		nameID := binary.BigEndian.Uint16(entry[6:])
+		length := int(binary.BigEndian.Uint16(entry[8:]))
+		offset := storage + int(binary.BigEndian.Uint16(entry[10:]))
+		if (nameID != basicID && nameID != typographicID) || offset+length > len(table) {
+			continue
+		}
+
+		score := 1
+		if nameID == typographicID {
+			score += 4
+		}
+		if platform == 3 {
+			score += 2
+			if language == 0x409 {
+				score++
+			}
+		}
+		if score <= bestScore {
+			continue
+		}
+
+		raw := table[offset : offset+length]
+		switch platform {
+		case 0, 3: // UTF-16BE
+			units := make([]uint16, len(raw)/2)
+			for j := range units {
+				units[j] = binary.BigEndian.Uint16(raw[2*j:])
+			}
+			best = string(utf16.Decode(units))
+		case 1: // Mac Roman，family名称通常是ASCII
+			best = string(raw)
+		default:
+			continue
+		}
+		bestScore = score
+	}
+	return best
+}
+
+func pad4(n int) int {
+	return (n + 3) &^ 3
+}
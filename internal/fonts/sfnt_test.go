@@ -0,0 +1,238 @@
+package fonts
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+	"testing"
+	"unicode/utf16"
+)
+
+// nameRecord name表中的一条名称
+type nameRecord struct {
+	platform, language, nameID uint16
+	text                       string
+}
+
+// nameTable 生成name表，Mac平台的名称按ASCII编码，其他平台按UTF-16BE编码
+func nameTable(records ...nameRecord) []byte {
+	var storage []byte
+	table := make([]byte, 6+12*len(records))
+	binary.BigEndian.PutUint16(table[2:], uint16(len(records)))
+	binary.BigEndian.PutUint16(table[4:], uint16(len(table)))
+	for i, record := range records {
+		var raw []byte
+		if record.platform == 1 {
+			raw = []byte(record.text)
+		} else {
+			for _, unit := range utf16.Encode([]rune(record.text)) {
+				raw = binary.BigEndian.AppendUint16(raw, unit)
+			}
+		}
+		entry := table[6+12*i:]
+		binary.BigEndian.PutUint16(entry[0:], record.platform)
+		binary.BigEndian.PutUint16(entry[4:], record.language)
+		binary.BigEndian.PutUint16(entry[6:], record.nameID)
+		binary.BigEndian.PutUint16(entry[8:], uint16(len(raw)))
+		binary.BigEndian.PutUint16(entry[10:], uint16(len(storage)))
+		storage = append(storage, raw...)
+	}
+	return append(table, storage...)
+}
+
+// windowsNames Windows平台美式英语的family和subfamily名称
+func windowsNames(family, subfamily string) []byte {
+	return nameTable(nameRecord{3, 0x409, 1, family}, nameRecord{3, 0x409, 2, subfamily})
+}
+
+// buildFont 生成只包含给定表的最小sfnt字体，表按标签排列并对齐到4字节
+func buildFont(tables map[string][]byte) []byte {
+	tags := make([]string, 0, len(tables))
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	out := make([]byte, sfntHeaderSize+sfntRecordSize*len(tags))
+	binary.BigEndian.PutUint32(out, 0x00010000)
+	binary.BigEndian.PutUint16(out[4:], uint16(len(tags)))
+	for i, tag := range tags {
+		entry := out[sfntHeaderSize+sfntRecordSize*i:]
+		copy(entry, tag)
+		binary.BigEndian.PutUint32(entry[8:], uint32(len(out)))
+		binary.BigEndian.PutUint32(entry[12:], uint32(len(tables[tag])))
+		out = append(out, tables[tag]...)
+		out = append(out, make([]byte, pad4(len(out))-len(out))...)
+	}
+	return out
+}
+
+// buildCollection 把多个字体依次放在.ttc的偏移表之后，表的偏移改为相对于整个文件
+func buildCollection(fonts ...[]byte) []byte {
+	out := make([]byte, ttcHeaderSize+4*len(fonts))
+	copy(out, "ttcf")
+	binary.BigEndian.PutUint32(out[4:], 0x00010000)
+	binary.BigEndian.PutUint32(out[8:], uint32(len(fonts)))
+	for i, font := range fonts {
+		base := len(out)
+		binary.BigEndian.PutUint32(out[ttcHeaderSize+4*i:], uint32(base))
+		font = append([]byte(nil), font...)
+		count := int(binary.BigEndian.Uint16(font[4:]))
+		for j := 0; j < count; j++ {
+			entry := font[sfntHeaderSize+sfntRecordSize*j+8:]
+			binary.BigEndian.PutUint32(entry, binary.BigEndian.Uint32(entry)+uint32(base))
+		}
+		out = append(out, font...)
+	}
+	return out
+}
+
+func fileOf(data []byte) sfntFile {
+	return sfntFile{bytes.NewReader(data), int64(len(data))}
+}
+
+func TestFaceNames(t *testing.T) {
+	tests := []struct {
+		name              string
+		table             []byte
+		family, subfamily string
+	}{
+		{"windows", windowsNames("Noto Sans CJK SC", "Bold"), "Noto Sans CJK SC", "Bold"},
+		{"typographic preferred", nameTable(
+			nameRecord{3, 0x409, 1, "Noto Sans CJK SC Black"},
+			nameRecord{3, 0x409, 2, "Regular"},
+			nameRecord{3, 0x409, 16, "Noto Sans CJK SC"},
+			nameRecord{3, 0x409, 17, "Black"},
+		), "Noto Sans CJK SC", "Black"},
+		{"windows preferred over mac", nameTable(
+			nameRecord{1, 0, 1, "Mac Name"},
+			nameRecord{3, 0x804, 1, "微软雅黑"},
+			nameRecord{3, 0x409, 1, "Microsoft YaHei"},
+		), "Microsoft YaHei", ""},
+		{"mac only", nameTable(nameRecord{1, 0, 1, "PingFang SC"}, nameRecord{1, 0, 2, "Regular"}), "PingFang SC", "Regular"},
+		{"unicode platform", nameTable(nameRecord{0, 0, 1, "文泉驿微米黑"}), "文泉驿微米黑", ""},
+		{"unknown platform", nameTable(nameRecord{2, 0, 1, "ISO"}), "", ""},
+		{"no name table", nil, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tables := map[string][]byte{"head": make([]byte, 54)}
+			if tt.table != nil {
+				tables["name"] = tt.table
+			}
+			family, subfamily := fileOf(buildFont(tables)).faceNames(0)
+			if family != tt.family || subfamily != tt.subfamily {
+				t.Errorf("faceNames = %q, %q; want %q, %q", family, subfamily, tt.family, tt.subfamily)
+			}
+		})
+	}
+}
+
+func TestExtractFont(t *testing.T) {
+	glyphs := []byte("glyph data, not a multiple of 4")
+	regular := buildFont(map[string][]byte{"glyf": glyphs, "name": windowsNames("Noto Sans CJK SC", "Regular")})
+	mono := buildFont(map[string][]byte{"glyf": glyphs, "name": windowsNames("Noto Sans Mono CJK SC", "Regular")})
+	collection := fileOf(buildCollection(regular, mono))
+
+	if count, err := collection.faceCount(); count != 2 || err != nil {
+		t.Fatalf("faceCount = %d, %v", count, err)
+	}
+	for index, want := range []string{"Noto Sans CJK SC", "Noto Sans Mono CJK SC"} {
+		if family, _ := collection.faceNames(index); family != want {
+			t.Errorf("face %d family = %q, want %q", index, family, want)
+		}
+
+		face, err := collection.extractFont(index)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// 取出的字体是独立的sfnt文件：表的偏移相对于它自身并对齐到4字节
+		extracted := fileOf(face)
+		if count, _ := extracted.faceCount(); count != 1 {
+			t.Errorf("face %d is still a collection", index)
+		}
+		if family, _ := extracted.faceNames(0); family != want {
+			t.Errorf("extracted face %d family = %q, want %q", index, family, want)
+		}
+		records, err := extracted.tableRecords(0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, record := range records {
+			if record.offset%4 != 0 {
+				t.Errorf("face %d table %s is not aligned: %d", index, record.tag, record.offset)
+			}
+			if record.tag == "glyf" && !bytes.Equal(face[record.offset:record.offset+record.length], glyphs) {
+				t.Errorf("face %d glyf table = %q", index, face[record.offset:record.offset+record.length])
+			}
+		}
+	}
+
+	// 单个字体原样返回
+	if face, err := fileOf(regular).extractFont(0); err != nil || !bytes.Equal(face, regular) {
+		t.Errorf("extracting a single font = %v", err)
+	}
+}
+
+func TestInvalidFonts(t *testing.T) {
+	font := buildFont(map[string][]byte{"name": windowsNames("Noto Sans CJK SC", "Regular")})
+	collection := buildCollection(font)
+
+	// patch 复制data并在offset处写入value
+	patch := func(data []byte, offset int, value uint32) []byte {
+		data = append([]byte(nil), data...)
+		binary.BigEndian.PutUint32(data[offset:], value)
+		return data
+	}
+
+	tests := []struct {
+		name  string
+		data  []byte
+		index int
+	}{
+		{"empty", nil, 0},
+		{"truncated font header", font[:8], 0},
+		{"truncated table directory", font[:sfntHeaderSize+4], 0},
+		{"table beyond file", patch(font, sfntHeaderSize+12, 1<<20), 0},
+		{"truncated collection header", collection[:10], 0},
+		{"collection with too many fonts", patch(collection, 8, 1<<30), 0},
+		{"font offset beyond file", patch(collection, ttcHeaderSize, uint32(len(collection))), 0},
+		{"truncated collection", collection[:len(collection)-8], 0},
+		{"index out of range", collection, 1},
+		{"negative index", collection, -1},
+		{"index in single font", font, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := fileOf(tt.data)
+			if face, err := file.extractFont(tt.index); err == nil {
+				t.Errorf("extractFont = %d bytes, want an error", len(face))
+			}
+			if family, subfamily := file.faceNames(tt.index); family != "" || subfamily != "" {
+				t.Errorf("faceNames = %q, %q; want nothing", family, subfamily)
+			}
+		})
+	}
+}
+
+func TestNameTableStringTruncated(t *testing.T) {
+	table := windowsNames("Noto Sans CJK SC", "Regular")
+	tests := []struct {
+		name  string
+		table []byte
+		want  string
+	}{
+		{"complete", table, "Noto Sans CJK SC"},
+		{"too short", table[:4], ""},
+		{"records cut off", table[:6+12], ""},
+		{"string cut off", table[:len(table)-4], "Noto Sans CJK SC"},
+		{"string beyond table", table[:6+24+4], ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nameTableString(tt.table, 1, 16); got != tt.want {
+				t.Errorf("nameTableString = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
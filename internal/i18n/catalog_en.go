@@ -0,0 +1,241 @@
+package i18n
+
+// english 英文翻译表，键为代码中的中文原文
+var english = map[string]string{
+	"请打开配置文件...":       "Open a configuration file...",
+	"  |  项目: %s":      "  |  Project: %s",
+	"当前文件: %s":         "Current file: %s",
+	"无法加载Schema文件: %v": "Failed to load schema file: %v",
+	"Schema文件已成功加载！\n\n文件路径: %s\n配置分组数: %d\n支持增强功能: 描述信息、提示、可编辑下拉框": "Schema loaded successfully!\n\nFile: %s\nSections: %d\nEnhanced features: descriptions, tooltips, editable dropdowns",
+	"Schema加载成功":   "Schema Loaded",
+	"无法加载配置文件: %v": "Failed to load configuration file: %v",
+	"配置文件已成功加载！\n\n文件路径: %s\n配置项数: %d\n自动识别分组数: %d": "Configuration loaded successfully!\n\nFile: %s\nEntries: %d\nDetected sections: %d",
+	"打开成功":         "Opened",
+	"Schema模式: %s": "Schema mode: %s",
+	"没有可保存的配置数据":   "There is no configuration data to save",
+	"配置已成功保存并覆盖原文件！\n\nYAML配置: %s\nDHF配置: %s": "Configuration saved, original files overwritten!\n\nYAML config: %s\nDHF config: %s",
+	"配置保存成功！\n\nYAML配置: %s\nDHF配置: %s":        "Configuration saved!\n\nYAML config: %s\nDHF config: %s",
+	"保存成功": "Saved",
+	"历史版本": "History",
+	"请先打开或保存一个配置文件":                  "Open or save a configuration file first",
+	"%s 暂无历史版本\n\n每次保存时会自动保留最近%d个版本": "%s has no history yet\n\nThe latest %d versions are kept automatically on every save",
+	"选择要恢复的版本（当前版本会先保存为历史版本）：":       "Choose a version to restore (the current version is kept in history first):",
+	"恢复历史版本":   "Restore Version",
+	"恢复":       "Restore",
+	"取消":       "Cancel",
+	"恢复失败: %v": "Restore failed: %v",
+	"动态配置":     "Dynamic Configuration",
+	"无操作":      "No action",
+	"接听":       "Answer",
+	"挂断":       "Hang up",
+	"音量+":      "Volume +",
+	"音量-":      "Volume -",
+	"播放/暂停":    "Play/Pause",
+	"下一首":      "Next track",
+	"上一首":      "Previous track",
+	"打开Siri":   "Open Siri",
+	"蓝灯常亮":     "Blue solid",
+	"红灯常亮":     "Red solid",
+	"绿灯常亮":     "Green solid",
+	"蓝灯快闪":     "Blue fast blink",
+	"红灯慢闪":     "Red slow blink",
+	"基础配置":     "Basic Settings",
+	"按键配置":     "Key Actions",
+	"LED配置":    "LED Settings",
+	"工厂设置":     "Factory Settings",
+	"高级设置":     "Advanced Settings",
+	"通话场景":     "Call Scenario",
+	"音乐场景":     "Music Scenario",
+	"连接状态":     "Connection Status",
+	"系统事件":     "System Events",
+	"通话事件":     "Call Events",
+	"TWS已连接":   "TWS Connected",
+	"TWS未连接":   "TWS Disconnected",
+	"IC型号":     "IC Model",
+	"VM操作":     "VM Operation",
+	"功放控制":     "PA Control",
+	"低电提醒时间":   "Low Battery Warning Time",
+	"通话中单击":    "Click During Call",
+	"来电单击":     "Click on Incoming Call",
+	"蓝牙已连接":    "Bluetooth Connected",
+	"重置模式":     "Reset Mode",
+	"自动开机":     "Auto Power On",
+	"配置目录，或通配符如 configs/*.yaml": "Config directory, or a glob such as configs/*.yaml",
+	"schema文件":        "Schema file",
+	"留空则输出到各配置文件旁":    "Leave empty to write next to each configuration",
+	"只校验，不生成输出":       "Validate only, do not generate output",
+	"浏览":              "Browse",
+	"选择Schema文件":      "Select Schema File",
+	"%s  %s  （%d个问题）": "%s  %s  (%d issues)",
+	"选择一个结果查看详情":      "Select a result to see details",
+	"导出报告":            "Export Report",
+	"开始":              "Start",
+	"批量生成":            "Batch Generate",
+	"请选择配置目录（或通配符）和schema文件": "Choose a configuration directory (or glob) and a schema file",
+	"正在处理%d个配置...":           "Processing %d configurations...",
+	"共%d个配置：通过%d，警告%d，失败%d":  "%d configurations: %d passed, %d warnings, %d failed",
+	"配置":         "Configs",
+	"选择配置目录":     "Select Configuration Directory",
+	"输出目录":       "Output directory",
+	"选择输出目录":     "Select Output Directory",
+	"输出模式":       "Output mode",
+	"报告格式":       "Report format",
+	"输出: ":       "Output: ",
+	"错误: %v":     "Error: %v",
+	"警告":         "Warning",
+	"错误":         "Error",
+	"没有发现问题":     "No issues found",
+	"无法写入报告: %v": "Failed to write report: %v",
+	"✓ 通过":       "✓ Passed",
+	"! 警告":       "! Warning",
+	"✗ 失败":       "✗ Failed",
+	"仅显示已修改":     "Modified only",
+	"全部恢复默认":     "Reset All",
+	"全部配置":       "All Settings",
+	"欢迎":         "Welcome",
+	"从左侧选择一个配置分组开始编辑。": "Select a section on the left to start editing.",
+	"未加载schema":             "No schema loaded",
+	"找不到分组: %s":             "Section not found: %s",
+	"在下方修改本分组的配置":           "Edit this section's settings below",
+	"本分组没有修改过的配置项":          "No modified settings in this section",
+	"找不到子分组: %s":            "Group not found: %s",
+	"在下方修改本子分组的配置":          "Edit this group's settings below",
+	"↺ 恢复默认":                "↺ Reset",
+	"字段说明":                  "Field Description",
+	"帮助信息":                  "Help",
+	"选择预设值...":              "Choose a preset...",
+	"恢复本组默认值":               "Reset Group to Defaults",
+	"（仅显示已修改的配置项）":          "(showing modified settings only)",
+	"恢复默认值":                 "Reset to Defaults",
+	"%s中没有修改过的配置项":          "No modified settings in %s",
+	"将%s中%d个已修改的配置项恢复为默认值？": "Reset %[2]d modified settings in %[1]s to their defaults?",
+	"版本 %s":                 "Version %s",
+	"通用配置管理工具\n将复杂配置文件转换为友好的图形界面\n\n支持YAML的现代化可视化配置工具": "General-purpose configuration tool\nTurns complex configuration files into a friendly GUI\n\nA modern visual editor for YAML configurations",
+	"关于":                      "About",
+	"打开配置":                    "Open",
+	"最近文件":                    "Recent",
+	"保存配置":                    "Save",
+	"复制到标签":                   "Copy to Tab",
+	"（暂无最近文件）":                "(no recent files)",
+	"启动时恢复上次会话":               "Restore last session on startup",
+	"清空列表":                    "Clear List",
+	"文件不存在或无法访问: %v":          "File does not exist or cannot be accessed: %v",
+	"选择配置文件":                  "Select Configuration File",
+	"配置分组":                    "Sections",
+	"YAML配置文件":                "YAML configuration files",
+	"用户取消了文件选择: %w":           "file selection canceled: %w",
+	"文件对话框错误: %v":             "File dialog error: %v",
+	"用户取消了目录选择: %w":           "directory selection canceled: %w",
+	"目录对话框错误: %v":             "Directory dialog error: %v",
+	"请选择YAML格式文件（.yaml或.yml）": "Please choose a YAML file (.yaml or .yml)",
+	"%s 在上次生成后被手工修改了%d项，直接保存会丢失这些修改。\n\n• 导回并保存：把conf中的修改写入YAML配置后再保存\n• 覆盖：丢弃conf中的修改\n• 取消：不保存任何文件": "%s has %d hand edits since it was generated; saving now would lose them.\n\n• Import and Save: write the conf edits back into the YAML, then save\n• Overwrite: discard the conf edits\n• Cancel: save nothing",
+	"conf文件已被手工修改":     "Conf File Was Edited",
+	"导回并保存":            "Import and Save",
+	"覆盖":               "Overwrite",
+	"已取消保存: %s 中有手工修改": "Save canceled: %s has hand edits",
+	"%s  已删除（原值 %s）":   "%s  removed (was %s)",
+	"%s  新增 = %s":      "%s  added = %s",
+	"新配置 (%s)":         "New Config (%s)",
+	"新配置":              "New Config",
+	"未命名":              "Untitled",
+	"关闭标签页":            "Close Tab",
+	"%s 有%d项未保存的修改，确定要关闭吗？": "%s has %d unsaved changes. Close anyway?",
+	"已打开项目 %s":               "Opened project %s",
+	"%s 已打开，已切换到对应标签页":       "%s is already open; switched to its tab",
+	"已绑定配置 %s 与 Schema %s":   "Bound config %s to schema %s",
+	"已加载Schema %s":           "Loaded schema %s",
+	"已打开配置 %s":               "Opened config %s",
+	"无法加载拖入的文件: %v":          "Failed to load dropped file: %v",
+	"无法导入conf文件: %v":         "Failed to import conf file: %v",
+	"已忽略: %s":                "Ignored: %s",
+	"拖放打开":                   "Drop to Open",
+	"%s  |  已补全%d项默认值（尚未保存）": "%s  |  filled in %d defaults (not saved yet)",
+	"已从 %s 导入%d项（尚未保存）":      "Imported %[2]d entries from %[1]s (not saved yet)",
+	"导入conf":                 "Import Conf",
+	"已从 %s 导入%d项配置":          "Imported %[2]d entries from %[1]s",
+	"\n%d个键无法对应到schema字段，已按一级配置项导入: %s":      "\n%d keys did not match any schema field and were imported as top-level entries: %s",
+	"无法加载项目文件: %v":                           "Failed to load project file: %v",
+	"项目 %s 中有%d个配置无法打开:\n%s":                 "%d configurations in project %s could not be opened:\n%s",
+	"已打开项目 %s（%d个配置）":                        "Opened project %s (%d configurations)",
+	"Schema已在外部修改但无法加载: %s":                  "Schema changed on disk but could not be loaded: %s",
+	"Schema已自动重新加载: %s":                      "Schema reloaded: %s",
+	"配置文件已在外部修改但无法加载: %s":                    "Configuration changed on disk but could not be loaded: %s",
+	"已合并外部修改: %s（本地修改%d项，其中%d项覆盖了外部修改，尚未保存）": "Merged external changes: %s (%d local edits, %d of them override external changes; not saved yet)",
+	"配置文件已自动重新加载: %s":                        "Configuration reloaded: %s",
+	"%s 已在外部被修改（例如编辑器保存或git pull）。\n\n你有%d项未保存的修改，请选择处理方式：\n• 合并：采用磁盘上的新版本，再应用你修改过的字段\n• 使用磁盘版本：放弃你的修改\n• 保留我的版本：忽略外部修改，下次保存时覆盖": "%s was changed outside the editor (for example by another editor or git pull).\n\nYou have %d unsaved changes. Choose how to continue:\n• Merge: take the new version from disk, then reapply the fields you changed\n• Use Disk Version: discard your changes\n• Keep Mine: ignore the external change and overwrite it on the next save",
+	"文件已在外部修改": "File Changed on Disk",
+	"合并":       "Merge",
+	"使用磁盘版本":   "Use Disk Version",
+	"保留我的版本":   "Keep Mine",
+	"已忽略外部修改: %s（保存时将覆盖）": "Ignored external change: %s (will be overwritten on save)",
+	"复制配置值": "Copy Values",
+	"当前标签页没有可复制的配置值":     "The current tab has no values to copy",
+	"请先在其他标签页中打开要复制到的配置": "Open the target configuration in another tab first",
+	"复制到":              "Copy to",
+	"范围":               "Scope",
+	"复制":               "Copy",
+	"已复制%d项到 %s（尚未保存）": "Copied %d entries to %s (not saved yet)",
+	"当前分组":             "Current section",
+	"全部配置项":            "All entries",
+	"设置":               "Settings",
+	"主题":               "Theme",
+	"跟随系统":             "System",
+	"浅色":               "Light",
+	"深色":               "Dark",
+	"高对比度":             "High Contrast",
+	"字体大小":             "Font size",
+	"界面密度":             "Density",
+	"舒适":               "Comfortable",
+	"紧凑":               "Compact",
+	"字体文件":             "Font file",
+	"留空则自动查找中文字体":      "Leave empty to find a Chinese font automatically",
+	"选择字体文件":           "Select Font File",
+	"当前字体: %s":         "Current font: %s",
+	"未找到中文字体":          "no Chinese font found",
+	"环境变量%s已指定字体，优先于此设置": "The %s environment variable sets a font and takes precedence over this setting",
+	"确定":       "OK",
+	"跳转到字段":    "Go to Field",
+	"请先打开配置文件": "Open a configuration file first",
+	"输入字段名称或路径，↑↓选择，Enter跳转":  "Type a field name or path, ↑↓ to choose, Enter to jump",
+	"每个单元格是一个配置项，可以把一行复制到另一行": "Each cell is a setting; a row can be copied to another row",
+	"⧉ 复制到…": "⧉ Copy to…",
+	"复制行：%s": "Copy Row: %s",
+	"复制行":    "Copy Row",
+	"以下配置项没有对应的选项，未复制：\n%s": "These settings have no matching option and were not copied:\n%s",
+	"选择颜色…":        "Pick Color…",
+	"选择颜色":         "Pick a Color",
+	"选择文件":         "Choose File",
+	"键":            "Key",
+	"值":            "Value",
+	"+ 添加":         "+ Add",
+	"🗒 备注":         "🗒 Note",
+	"🗒 编辑备注":       "🗒 Edit note",
+	"为什么使用这个值？":    "Why is this value used?",
+	"备注":           "Note",
+	"作者":           "Author",
+	"日期":           "Date",
+	"备注：%s":        "Note: %s",
+	"变更记录":         "Change History",
+	"读取变更记录失败: %v": "Failed to read change history: %v",
+	"%s 暂无变更记录\n\n每次保存时会把修改过的配置项记录到 %s": "%s has no change history yet\n\nEach save records the changed settings in %s",
+	"撤销":                                "Revert",
+	"%s → %s\n%s · %s · ConfigCraft %s": "%s → %s\n%s · %s · ConfigCraft %s",
+	"撤销一项修改会把该配置项恢复为修改前的值（尚未保存）：": "Reverting a change restores the setting to its previous value (not saved yet):",
+	"变更记录：%s":           "Change History: %s",
+	"（无）":               "(none)",
+	"把 %s 从 %s 恢复为 %s？": "Restore %s from %s to %s?",
+	"%s 在这次修改之后又被修改过，当前值 %s 将被恢复为 %s，确定吗？": "%s was changed again after this change. Its current value %s will be restored to %s. Continue?",
+	"撤销修改": "Revert Change",
+	"已撤销 %s 的修改（尚未保存）":                  "Reverted the change to %s (not saved yet)",
+	"（不完整，无法恢复）":                        "(incomplete, cannot be restored)",
+	"该版本保存时部分文件（如conf）还不存在，无法与YAML一起恢复": "Some files of this version (such as the conf) did not exist yet, so it cannot be restored together with the YAML",
+	"批量生成失败: %v":                        "Batch generation failed: %v",
+	"配置组":                               "Configuration Groups",
+	"配置选项":                              "Configuration Options",
+	"作者：%s":                             "Created by %s",
+	"缺少中文字体":                            "Chinese font not found",
+
+	// 同一原文在不同场合译法不同，键带上下文
+	Ctx("按钮", "关闭"): "Close",
+	Ctx("灯效", "关闭"): "Off",
+}
@@ -0,0 +1,132 @@
+// Package i18n 界面文字的多语言支持
+// 代码中以中文原文作为键调用T，英文等其他语言在对应的翻译表中查找，没有翻译时显示原文
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"configcraft/internal/models"
+)
+
+// 支持的界面语言
+const (
+	Chinese = "zh"
+	English = "en"
+)
+
+// Languages 支持的界面语言，按显示顺序
+var Languages = []string{Chinese, English}
+
+// LanguageNames 语言在语言选择中的显示名称（始终使用该语言本身的写法）
+var LanguageNames = map[string]string{
+	Chinese: "中文",
+	English: "English",
+}
+
+// catalogs 各语言的翻译表：中文原文 -> 译文
+var catalogs = map[string]map[string]string{
+	English: english,
+}
+
+var (
+	mu        sync.RWMutex
+	current   = Chinese
+	listeners []func()
+)
+
+// Language 当前界面语言
+func Language() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// SetLanguage 切换界面语言并通知所有监听者；不支持的语言按中文处理
+func SetLanguage(lang string) {
+	if _, supported := LanguageNames[lang]; !supported {
+		lang = Chinese
+	}
+
+	mu.Lock()
+	changed := current != lang
+	current = lang
+	notify := append([]func(){}, listeners...)
+	mu.Unlock()
+
+	if changed {
+		for _, fn := range notify {
+			fn()
+		}
+	}
+}
+
+// OnLanguageChanged 注册语言切换后的回调，用于刷新已显示的界面文字
+func OnLanguageChanged(fn func()) {
+	mu.Lock()
+	defer mu.Unlock()
+	listeners = append(listeners, fn)
+}
+
+// DetectLanguage 根据系统环境变量推断界面语言，无法判断时使用中文
+func DetectLanguage() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := strings.ToLower(os.Getenv(name))
+		if value == "" {
+			continue
+		}
+		if strings.HasPrefix(value, "zh") {
+			return Chinese
+		}
+		if value != "c" && value != "posix" {
+			return English
+		}
+	}
+	return Chinese
+}
+
+// Ctx 带上下文的界面文字键：同一中文原文在不同场合需要不同译文时使用，如Ctx("按钮", "关闭")
+// 翻译表中以带上下文的键查找，没有翻译时显示原文
+func Ctx(context, text string) string {
+	return context + models.ContextSeparator + text
+}
+
+// T 翻译界面文字；有参数时按fmt.Sprintf格式化译文
+func T(text string, args ...interface{}) string {
+	mu.RLock()
+	translated, exists := catalogs[current][text]
+	mu.RUnlock()
+	if !exists {
+		translated = models.SourceText(text)
+	}
+
+	if len(args) == 0 {
+		return translated
+	}
+	return fmt.Sprintf(translated, args...)
+}
+
+// Errorf 用翻译后的格式创建错误，支持%w
+func Errorf(format string, args ...interface{}) error {
+	return fmt.Errorf(T(format), args...)
+}
+
+// Local 返回schema文本在当前语言下的写法
+func Local(text models.Text) string {
+	return text.In(Language())
+}
+
+// Text 把界面内置的中文文字转换为可本地化的schema文本，包含所有语言的翻译
+// 用于程序生成的schema（如根据配置内容动态生成），切换语言后同样能显示对应的文字
+func Text(text string) models.Text {
+	source := models.SourceText(text)
+	translations := map[string]string{Chinese: source}
+	for lang, catalog := range catalogs {
+		if translated, exists := catalog[text]; exists {
+			translations[lang] = translated
+		}
+	}
+	return models.Text{Default: source, Translations: translations}
+}
@@ -0,0 +1,29 @@
+package i18n
+
+import "testing"
+
+func TestContextKeys(t *testing.T) {
+	defer SetLanguage(Language())
+
+	tests := []struct {
+		lang, key, want string
+	}{
+		{Chinese, Ctx("按钮", "关闭"), "关闭"},
+		{Chinese, Ctx("灯效", "关闭"), "关闭"},
+		{English, Ctx("按钮", "关闭"), "Close"},
+		{English, Ctx("灯效", "关闭"), "Off"},
+		{English, Ctx("未翻译", "关闭"), "关闭"},
+		{English, "  |  项目: %s", "  |  Project: %s"},
+	}
+	for _, tt := range tests {
+		SetLanguage(tt.lang)
+		if got := T(tt.key); got != tt.want {
+			t.Errorf("%s: T(%q) = %q, want %q", tt.lang, tt.key, got, tt.want)
+		}
+	}
+
+	text := Text(Ctx("灯效", "关闭"))
+	if text.Default != "关闭" || text.In(English) != "Off" || text.In(Chinese) != "关闭" {
+		t.Errorf("Text = %+v", text)
+	}
+}
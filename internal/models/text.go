@@ -0,0 +1,91 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ContextSeparator 界面文字键中上下文与原文的分隔符（与gettext的msgctxt相同）
+// 同一原文在不同场合译法不同时，键写成"上下文\x04原文"，如按钮上的"关闭"（Close）和灯效的"关闭"（Off）
+const ContextSeparator = "\x04"
+
+// SourceText 去掉界面文字键中的上下文，返回显示用的原文
+func SourceText(key string) string {
+	if _, text, found := strings.Cut(key, ContextSeparator); found {
+		return text
+	}
+	return key
+}
+
+// Text 可本地化的显示文本
+// YAML中可以写成字符串，也可以写成按语言区分的映射：
+//
+//	label: 按键配置
+//	label: {zh: 按键配置, en: Key Actions}
+type Text struct {
+	Default      string            // 没有对应语言的翻译时使用：字符串形式的值，或映射中的zh（没有时为en或第一个）
+	Translations map[string]string // 语言代码 -> 文本
+}
+
+// NewText 创建只有一种写法的文本
+func NewText(text string) Text {
+	return Text{Default: text}
+}
+
+// In 返回指定语言的文本，没有该语言时返回默认文本
+func (t Text) In(lang string) string {
+	if text, exists := t.Translations[lang]; exists && text != "" {
+		return text
+	}
+	return t.Default
+}
+
+// String 返回默认文本
+func (t Text) String() string {
+	return t.Default
+}
+
+// IsZero 没有任何文本，YAML的omitempty据此省略空文本
+func (t Text) IsZero() bool {
+	return t.Default == "" && len(t.Translations) == 0
+}
+
+func (t *Text) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		*t = Text{Default: node.Value}
+		return nil
+	case yaml.MappingNode:
+		var translations map[string]string
+		if err := node.Decode(&translations); err != nil {
+			return err
+		}
+		*t = Text{Translations: translations}
+		for _, lang := range []string{"zh", "en"} {
+			if text := translations[lang]; text != "" {
+				t.Default = text
+				return nil
+			}
+		}
+		langs := make([]string, 0, len(translations))
+		for lang := range translations {
+			langs = append(langs, lang)
+		}
+		sort.Strings(langs)
+		if len(langs) > 0 {
+			t.Default = translations[langs[0]]
+		}
+		return nil
+	}
+	return fmt.Errorf("line %d: text must be a string or a map of language to string", node.Line)
+}
+
+func (t Text) MarshalYAML() (interface{}, error) {
+	if len(t.Translations) == 0 {
+		return t.Default, nil
+	}
+	return t.Translations, nil
+}
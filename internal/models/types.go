@@ -1,41 +1,125 @@
 package models
 
+import "gopkg.in/yaml.v3"
+
 type ConfigSection struct {
-	Name   string                 `yaml:"name"`
+	Name   Text                   `yaml:"name"`
 	Icon   string                 `yaml:"icon"`
 	Fields map[string]ConfigField `yaml:"fields"`
 	Groups map[string]ConfigGroup `yaml:"groups"`
 }
 
 type ConfigGroup struct {
-	Name   string                 `yaml:"name"`
-	Fields map[string]ConfigField `yaml:"fields"`
+	Name    Text                   `yaml:"name"`
+	Fields  map[string]ConfigField `yaml:"fields"`
+	Layout  string                 `yaml:"layout,omitempty"`  // 显示方式：为空时逐个显示字段，matrix按行列显示为表格
+	Rows    []MatrixAxis           `yaml:"rows,omitempty"`    // matrix布局的行顺序和名称，可省略
+	Columns []MatrixAxis           `yaml:"columns,omitempty"` // matrix布局的列顺序和名称，可省略
+}
+
+// MatrixAxis matrix布局的一行或一列，字段的row、column引用其key
+type MatrixAxis struct {
+	Key   string `yaml:"key"`
+	Label Text   `yaml:"label,omitempty"` // 为空时显示key
 }
 
 type ConfigField struct {
 	Type        string                 `yaml:"type"`
-	Label       string                 `yaml:"label"`
-	Description string                 `yaml:"description,omitempty"`  // 字段描述信息
-	Tooltip     string                 `yaml:"tooltip,omitempty"`      // 鼠标悬停提示
-	Placeholder string                 `yaml:"placeholder,omitempty"`  // 输入框占位符
+	Label       Text                   `yaml:"label"`                  // 以下文本都可以写成按语言区分的映射
+	Description Text                   `yaml:"description,omitempty"`  // 字段描述信息
+	Tooltip     Text                   `yaml:"tooltip,omitempty"`      // 鼠标悬停提示
+	Placeholder Text                   `yaml:"placeholder,omitempty"`  // 输入框占位符
 	Options     []ConfigOption         `yaml:"options,omitempty"`
 	Default     interface{}            `yaml:"default,omitempty"`
 	Required    bool                   `yaml:"required,omitempty"`
 	Min         *int                   `yaml:"min,omitempty"`
 	Max         *int                   `yaml:"max,omitempty"`
+	OutputKey   string                 `yaml:"output_key,omitempty"`   // 生成文件中的键名，覆盖schema的命名规则
+	Format      string                 `yaml:"format,omitempty"`       // 输出格式：flags为list或mask，color为html或hex
+	Extensions  []string               `yaml:"extensions,omitempty"`   // path字段可选择的文件扩展名，如[".bin", ".hex"]
+	Row         string                 `yaml:"row,omitempty"`          // matrix布局中所在的行
+	Column      string                 `yaml:"column,omitempty"`       // matrix布局中所在的列
 }
 
 type ConfigOption struct {
-	Value interface{} `yaml:"value"`
-	Label string      `yaml:"label"`
+	Value     interface{}   `yaml:"value"`
+	Label     Text          `yaml:"label"`
+	Animation *LedAnimation `yaml:"animation,omitempty"` // 灯效选项的动画，编辑器据此显示预览
+}
+
+// LedAnimation 灯效：colors中的颜色依次亮on毫秒、灭off毫秒
+// off为0且只有一种颜色时为常亮，colors为空时为熄灭；repeat为整个序列的播放次数，0为一直循环
+type LedAnimation struct {
+	Colors  []string `yaml:"colors,omitempty"` // 颜色名称（red、green、blue、orange等）或#RRGGBB
+	On      int      `yaml:"on,omitempty"`
+	Off     int      `yaml:"off,omitempty"`
+	Repeat  int      `yaml:"repeat,omitempty"`
+	Breathe bool     `yaml:"breathe,omitempty"` // 呼吸灯：每次点亮时亮度在on时间内渐亮再渐暗
 }
 
 type Schema struct {
 	SchemaVersion string                    `yaml:"schema_version"`
-	DisplayName   string                    `yaml:"display_name"`
+	DisplayName   Text                      `yaml:"display_name"`
+	Product       string                    `yaml:"product,omitempty"` // 产品名称，用于生成文件的文件头
+	Header        string                    `yaml:"header,omitempty"`  // 文件头模板，每行输出时加上注释前缀
+	Naming        NamingRule                `yaml:"naming,omitempty"`
+	Templates     map[string]string         `yaml:"templates,omitempty"` // 输出格式 -> 生成模板文件（相对于schema文件）
 	Sections      map[string]ConfigSection `yaml:"sections"`
 }
 
+// NamingRule 生成文件中键名的命名规则
+type NamingRule struct {
+	KeyTemplate string `yaml:"key_template,omitempty"` // 键名模板，可用{path}、{section}、{field}，默认"_{path}"
+	Separator   string `yaml:"separator,omitempty"`    // 替换路径中点号的分隔符，默认"_"
+	Case        string `yaml:"case,omitempty"`         // upper（默认）、lower、keep
+}
+
 type UserConfig struct {
 	Values map[string]interface{} `json:"values"`
-}
\ No newline at end of file
+	Notes  map[string]FieldNote   `yaml:"notes,omitempty" json:"notes,omitempty"` // 配置项路径 -> 备注
+
+	// Source 加载时的原始YAML文档树，保存时在其上修改值以保留注释、顺序和格式
+	Source *yaml.Node `yaml:"-" json:"-"`
+
+	// FilePath 加载或保存配置的路径，生成文件的文件头中记录其文件名
+	FilePath string `yaml:"-" json:"-"`
+}
+
+// FieldNote 配置项的备注，记录取值的原因以及由谁在何时写下
+type FieldNote struct {
+	Note   string `yaml:"note" json:"note"`
+	Author string `yaml:"author,omitempty" json:"author,omitempty"`
+	Date   string `yaml:"date,omitempty" json:"date,omitempty"` // 2006-01-02
+}
+
+// Project 项目文件（configcraft.project.yaml），描述一个产品的schema、各变体配置及其输出位置
+type Project struct {
+	Name    string          `yaml:"name"`
+	Schema  string          `yaml:"schema"`
+	Configs []ProjectConfig `yaml:"configs"`
+
+	// FilePath 项目文件路径，加载时记录；项目中的相对路径都相对于它所在的目录
+	FilePath string `yaml:"-"`
+}
+
+type ProjectConfig struct {
+	Name      string            `yaml:"name"`
+	Path      string            `yaml:"path"`
+	Schema    string            `yaml:"schema,omitempty"` // 覆盖项目的schema
+	Generator GeneratorSettings `yaml:"generator,omitempty"`
+	Outputs   []ProjectOutput   `yaml:"outputs,omitempty"` // 为空时输出到配置文件旁的同名.conf
+}
+
+type ProjectOutput struct {
+	Path     string `yaml:"path"`
+	Format   string `yaml:"format,omitempty"`   // 为空时使用generator中的格式
+	Mode     string `yaml:"mode,omitempty"`     // 输出模式：full、defaults、overrides，为空时使用generator中的模式
+	Template string `yaml:"template,omitempty"` // 生成模板文件，指定时忽略format
+}
+
+// GeneratorSettings 生成输出文件的设置
+type GeneratorSettings struct {
+	Format  string `yaml:"format,omitempty"`  // 输出格式，默认conf
+	Mode    string `yaml:"mode,omitempty"`    // 输出模式，默认full
+	Backups int    `yaml:"backups,omitempty"` // 覆盖输出文件前保留的历史版本数
+}
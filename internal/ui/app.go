@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -9,6 +10,8 @@ import (
 	"strings"
 	
 	"configcraft/internal/config"
+	"configcraft/internal/fonts"
+	"configcraft/internal/i18n"
 	"configcraft/internal/models"
 	"configcraft/internal/ui/components"
 	"configcraft/internal/version"
@@ -20,28 +23,43 @@ import (
 	"fyne.io/fyne/v2/widget"
 )
 
+// noFontMessage 没有找到中文字体时的提示
+// 正文固定用英文，中文在没有字体时无法显示；标题随界面语言翻译（没有字体时默认为英文界面）
+var noFontMessage = "No font that can display Chinese text was found, so Chinese labels will show as boxes.\n" +
+	"Install a CJK font (e.g. Noto Sans CJK or WenQuanYi Micro Hei),\n" +
+	"or set " + fonts.EnvFont + " to a .ttf/.otf/.ttc font file and restart."
+
+// backupCount 每次保存时保留的历史版本数
+const backupCount = 5
+
+// appID 应用唯一标识，Fyne的偏好设置（最近文件、会话等）按此ID存储
+const appID = "com.configcraft.app"
+
 type App struct {
+	*document // 当前标签页的文档，App上的文件/编辑操作都作用于它
+	
 	fyneApp    fyne.App
 	window     fyne.Window
-	parser     *config.Parser
-	schema     *models.Schema
-	userConfig *models.UserConfig
-	
-	tree       *components.ConfigTree
-	editor     *components.ConfigEditor
 	toolbar    *components.Toolbar
+	tabs       *container.DocTabs
+	documents  []*document // 所有标签页的文档，按打开顺序
 	
-	currentFilePath string // 记录当前打开的文件路径
 	statusLabel     *widget.Label // 状态栏：显示当前文件路径
 	versionLabel    *widget.Label // 版本信息标签
+	
+	watcher *fileWatcher // 监视所有标签页的配置文件和schema的外部修改
+	fonts      *fonts.Set // 界面使用的CJK字体
+	appearance appearance // 当前的外观设置
 }
 
 func NewApp() *App {
-	fyneApp := app.New()
+	fyneApp := app.NewWithID(appID)
 	fyneApp.SetIcon(nil)
 	
-	// 使用简化的中文主题支持
-	fyneApp.Settings().SetTheme(NewSimpleChineseTheme())
+	// 使用能显示中文的字体和保存的外观设置
+	fontSet := resolveFonts(fyneApp.Preferences())
+	settings := loadAppearance(fyneApp.Preferences())
+	fyneApp.Settings().SetTheme(newAppTheme(fontSet, settings))
 	
 	window := fyneApp.NewWindow("ConfigCraft")
 	
@@ -53,53 +71,48 @@ func NewApp() *App {
 	return &App{
 		fyneApp: fyneApp,
 		window:  window,
-		parser:  config.NewParser(),
+		fonts:      fontSet,
+		appearance: settings,
 	}
 }
 
 func (a *App) Initialize() error {
-	a.tree = components.NewConfigTree()
-	a.editor = components.NewConfigEditor()
+	// 先确定界面语言，之后创建的控件直接使用对应语言的文字
+	// 没有找到中文字体时默认使用英文界面，否则中文会显示为方框
+	defaultLanguage := i18n.DetectLanguage()
+	if !a.fonts.Found() {
+		defaultLanguage = i18n.English
+	}
+	i18n.SetLanguage(a.fyneApp.Preferences().StringWithFallback(prefLanguage, defaultLanguage))
+	i18n.OnLanguageChanged(a.retranslate)
+	
 	a.toolbar = components.NewToolbar()
+	a.setupTabs()
 	
 	// 初始化状态栏标签
-	a.statusLabel = widget.NewLabel("请打开配置文件...")
+	a.statusLabel = widget.NewLabel(i18n.T("请打开配置文件..."))
 	a.versionLabel = widget.NewLabel(version.GetVersionString())
 	
 	a.setupLayout()
 	a.setupCallbacks()
+	a.setupShortcuts()
+	a.refreshRecentFiles()
+	
+	watcher, err := newFileWatcher()
+	if err != nil {
+		// 监视失败不影响正常使用，只是没有自动重新加载
+		log.Printf("File watching disabled: %v", err)
+	} else {
+		a.watcher = watcher
+		go a.watchFiles()
+	}
 	
 	return nil
 }
 
 func (a *App) setupLayout() {
-	// 设置toolbar和editor的window引用
+	// 设置toolbar的window引用
 	a.toolbar.SetWindow(a.window)
-	a.editor.SetWindow(a.window)
-	
-	// 左侧区域：配置分组导航
-	leftPanel := container.NewBorder(
-		container.NewVBox(
-			widget.NewLabelWithStyle("Configuration Groups", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
-			widget.NewSeparator(),
-		),
-		nil, nil, nil,
-		a.tree.Container(),
-	)
-	
-	// 右侧区域：详细配置选项
-	rightPanel := container.NewBorder(
-		container.NewVBox(
-			widget.NewLabelWithStyle("Configuration Options", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
-			widget.NewSeparator(),
-		),
-		nil, nil, nil,
-		a.editor.Container(),
-	)
-	
-	// 创建主分割面板
-	mainSplit := container.NewHSplit(leftPanel, rightPanel)
-	mainSplit.SetOffset(0.3) // 左侧30%，右侧70%
 	
 	// 整体布局：工具栏在顶部，分界线，主要内容区域，底部状态栏
 	content := container.NewBorder(
@@ -116,8 +129,8 @@ func (a *App) setupLayout() {
 			nil,
 		),
 		nil, nil, // 左右留空
-		// 中心：主要内容区域
-		mainSplit,
+		// 中心：主要内容区域，每个标签页一个配置
+		a.tabs,
 	)
 	
 	a.window.SetContent(content)
@@ -125,11 +138,23 @@ func (a *App) setupLayout() {
 
 // updateStatusBar 更新状态栏显示
 func (a *App) updateStatusBar(filePath string) {
+	a.updateDocumentStatusBar(a.document, filePath)
+}
+
+// updateDocumentStatusBar 更新doc的状态栏显示
+func (a *App) updateDocumentStatusBar(doc *document, filePath string) {
 	if filePath == "" {
-		a.statusLabel.SetText("请打开配置文件...")
+		a.setDocumentStatus(doc, i18n.T("请打开配置文件..."))
 	} else {
 		displayPath := a.getRelativePath(filePath)
-		a.statusLabel.SetText(fmt.Sprintf("当前文件: %s", displayPath))
+		if doc.schemaFilePath != "" {
+			// 配置文件绑定了schema时一并显示
+			displayPath += fmt.Sprintf("  |  Schema: %s", filepath.Base(doc.schemaFilePath))
+		}
+		if doc.project != nil {
+			displayPath += i18n.T("  |  项目: %s", projectDisplayName(doc.project))
+		}
+		a.setDocumentStatus(doc, i18n.T("当前文件: %s", displayPath))
 	}
 }
 
@@ -160,26 +185,77 @@ func (a *App) getRelativePath(filePath string) string {
 }
 
 func (a *App) setupCallbacks() {
-	a.tree.SetSelectionCallback(func(nodeID string) {
-		a.editor.ShowSection(nodeID)
-	})
-	
 	a.toolbar.SetOpenCallback(func(filePath string) {
-		a.openConfigFile(filePath)
+		if config.IsProjectFile(filePath) {
+			a.openProject(filePath)
+			return
+		}
+		if a.prepareDocumentFor(filePath) {
+			a.openConfigFile(filePath)
+		}
 	})
 	
 	a.toolbar.SetSaveCallback(func(filePath string) {
 		a.saveConfigFile(filePath)
 	})
 	
+	a.toolbar.SetRestoreCallback(func() {
+		a.showRestoreDialog()
+	})
+	
+	a.toolbar.SetHistoryCallback(func() {
+		a.showHistoryDialog()
+	})
+	
+	a.toolbar.SetCopyCallback(func() {
+		a.showCopyDialog()
+	})
+	
+	a.toolbar.SetBatchCallback(func() {
+		a.showBatchDialog()
+	})
+	
+	a.toolbar.SetClearRecentCallback(func() {
+		a.clearRecentFiles()
+	})
+	
+	a.toolbar.SetRestoreSessionCallback(func(enabled bool) {
+		a.fyneApp.Preferences().SetBool(prefRestoreSession, enabled)
+	})
+	
+	a.window.SetOnDropped(a.onFilesDropped)
+	
+	a.window.SetOnClosed(func() {
+		a.saveSession()
+		// 在窗口的事件队列销毁前停止监视，之后不再有修改交给界面
+		if a.watcher != nil {
+			a.watcher.Close()
+		}
+	})
+	
 	a.toolbar.SetHasOpenFileCallback(func() bool {
 		return a.currentFilePath != ""
 	})
+	
+	a.toolbar.SetSettingsCallback(func() {
+		a.showSettingsDialog()
+	})
+	
+	a.toolbar.SetLanguageCallback(func(lang string) {
+		a.fyneApp.Preferences().SetString(prefLanguage, lang)
+		i18n.SetLanguage(lang)
+	})
 }
 
-func (a *App) refreshTree() {
-	if a.schema != nil {
-		a.tree.LoadSchema(a.schema)
+// retranslate 切换语言后刷新所有标签页中已显示的文字
+func (a *App) retranslate() {
+	a.toolbar.Retranslate()
+	for _, doc := range a.documents {
+		doc.retranslate()
+		a.refreshTabTitle(doc)
+	}
+	if a.currentFilePath != "" || a.isEmpty() {
+		a.updateStatusBar(a.currentFilePath)
 	}
 }
 
@@ -189,13 +265,19 @@ func (a *App) LoadSchema(filePath string) error {
 	}
 	
 	a.schema = a.parser.GetSchema()
+	a.schemaFilePath = filePath
 	a.tree.LoadSchema(a.schema)
 	a.editor.SetSchema(a.schema)
+	a.markClean(a.document)
 	
 	return nil
 }
 
 func (a *App) Run() {
+	a.restoreSession()
+	if !a.fonts.Found() {
+		a.showNoFontDialog()
+	}
 	a.window.ShowAndRun()
 }
 
@@ -203,59 +285,98 @@ func (a *App) Run() {
 func (a *App) openConfigFile(filePath string) {
 	log.Printf("Opening config file: %s", filePath)
 	
-	// 尝试作为schema文件加载
-	if err := a.parser.LoadSchema(filePath); err == nil {
-		// 成功加载为schema文件
-		a.schema = a.parser.GetSchema()
-		a.userConfig = &models.UserConfig{Values: make(map[string]interface{})}
-		a.currentFilePath = ""  // schema文件不是配置文件
-		a.editor.SetSchema(a.schema)
-		a.editor.SetConfig(a.userConfig)
-		
-		// 更新状态栏显示schema文件信息
-		a.statusLabel.SetText(fmt.Sprintf("Schema模式: %s", filepath.Base(filePath)))
-		
-		// 刷新界面
-		a.refreshTree()
-		
-		// 自动选择第一个section
-		if len(a.schema.Sections) > 0 {
-			sectionKeys := make([]string, 0, len(a.schema.Sections))
-			for sectionKey := range a.schema.Sections {
-				sectionKeys = append(sectionKeys, sectionKey)
-			}
-			// 使用相同的排序逻辑
-			sectionOrder := map[string]int{
-				"basic": 1, "call_actions": 2, "music_actions": 3, "led_config": 4, "special_functions": 5, "advanced": 6,
-			}
-			sort.Slice(sectionKeys, func(i, j int) bool {
-				orderI, existsI := sectionOrder[sectionKeys[i]]
-				orderJ, existsJ := sectionOrder[sectionKeys[j]]
-				if existsI && existsJ {
-					return orderI < orderJ
-				} else if existsI {
-					return true
-				} else if existsJ {
-					return false
-				}
-				return sectionKeys[i] < sectionKeys[j]
-			})
-			
-			a.editor.ShowSection(sectionKeys[0])
+	switch detectFileKind(filePath) {
+	case fileKindProject:
+		a.openProject(filePath)
+		return
+	case fileKindConf:
+		a.importConfFileWithMessage(filePath)
+		return
+	case fileKindSchema:
+		if err := a.loadSchemaFile(filePath); err != nil {
+			dialog.ShowError(i18n.Errorf("无法加载Schema文件: %v", err), a.window)
+			return
 		}
 		
 		// 显示成功消息
-		message := fmt.Sprintf("Schema文件已成功加载！\n\n文件路径: %s\n配置分组数: %d\n支持增强功能: 描述信息、提示、可编辑下拉框", 
+		message := i18n.T("Schema文件已成功加载！\n\n文件路径: %s\n配置分组数: %d\n支持增强功能: 描述信息、提示、可编辑下拉框", 
 			filePath, len(a.schema.Sections))
-		dialog.ShowInformation("Schema加载成功", message, a.window)
+		dialog.ShowInformation(i18n.T("Schema加载成功"), message, a.window)
 		return
 	}
 	
 	// 作为用户配置文件加载
+	if err := a.loadConfigFile(filePath); err != nil {
+		dialog.ShowError(i18n.Errorf("无法加载配置文件: %v", err), a.window)
+		return
+	}
+	
+	// 显示成功消息
+	message := i18n.T("配置文件已成功加载！\n\n文件路径: %s\n配置项数: %d\n自动识别分组数: %d", 
+		filePath, len(a.userConfig.Values), len(a.schema.Sections))
+	dialog.ShowInformation(i18n.T("打开成功"), message, a.window)
+}
+
+// loadSchemaFile 加载schema文件并以空配置进入Schema模式，不弹出提示
+func (a *App) loadSchemaFile(filePath string) error {
+	if err := a.parser.LoadSchema(filePath); err != nil {
+		return err
+	}
+	
+	// 成功加载为schema文件，新配置包含schema中所有字段的默认值
+	a.schema = a.parser.GetSchema()
+	a.userConfig = &models.UserConfig{Values: make(map[string]interface{})}
+	config.MaterializeDefaults(a.schema, a.userConfig)
+	a.currentFilePath = ""  // schema文件不是配置文件
+	a.schemaFilePath = filePath
+	a.editor.SetSchema(a.schema)
+	a.editor.SetConfig(a.userConfig)
+	a.markClean(a.document)
+	a.addRecentFile(filePath, true)
+	
+	// 更新状态栏显示schema文件信息
+	a.setStatus(i18n.T("Schema模式: %s", filepath.Base(filePath)))
+	
+	// 刷新界面
+	a.refreshTree()
+	a.showFirstSection()
+	
+	return nil
+}
+
+// showFirstSection 按固定的分组顺序显示第一个section
+func (a *App) showFirstSection() {
+	if len(a.schema.Sections) > 0 {
+		sectionKeys := make([]string, 0, len(a.schema.Sections))
+		for sectionKey := range a.schema.Sections {
+			sectionKeys = append(sectionKeys, sectionKey)
+		}
+		// 使用相同的排序逻辑
+		sectionOrder := map[string]int{
+			"basic": 1, "call_actions": 2, "music_actions": 3, "led_config": 4, "special_functions": 5, "advanced": 6,
+		}
+		sort.Slice(sectionKeys, func(i, j int) bool {
+			orderI, existsI := sectionOrder[sectionKeys[i]]
+			orderJ, existsJ := sectionOrder[sectionKeys[j]]
+			if existsI && existsJ {
+				return orderI < orderJ
+			} else if existsI {
+				return true
+			} else if existsJ {
+				return false
+			}
+			return sectionKeys[i] < sectionKeys[j]
+		})
+		
+		a.editor.ShowSection(sectionKeys[0])
+	}
+}
+
+// loadConfigFile 加载用户配置文件并根据内容动态生成schema，不弹出提示
+func (a *App) loadConfigFile(filePath string) error {
 	userConfig, err := a.parser.LoadUserConfig(filePath)
 	if err != nil {
-		dialog.ShowError(fmt.Errorf("无法加载配置文件: %v", err), a.window)
-		return
+		return err
 	}
 	
 	// 从配置文件内容动态生成schema
@@ -265,8 +386,11 @@ func (a *App) openConfigFile(filePath string) {
 	a.schema = dynamicSchema
 	a.userConfig = userConfig
 	a.currentFilePath = filePath // 记录当前文件路径
+	a.schemaFilePath = ""
 	a.editor.SetSchema(a.schema)
 	a.editor.SetConfig(a.userConfig)
+	a.markClean(a.document)
+	a.addRecentFile(filePath, false)
 	
 	// 更新状态栏显示当前配置文件
 	a.updateStatusBar(filePath)
@@ -282,10 +406,7 @@ func (a *App) openConfigFile(filePath string) {
 		}
 	}
 	
-	// 显示成功消息
-	message := fmt.Sprintf("配置文件已成功加载！\n\n文件路径: %s\n配置项数: %d\n自动识别分组数: %d", 
-		filePath, len(a.userConfig.Values), len(a.schema.Sections))
-	dialog.ShowInformation("打开成功", message, a.window)
+	return nil
 }
 
 // saveConfigFile 保存配置文件并生成conf文件 - 智能保存版本
@@ -298,42 +419,116 @@ func (a *App) saveConfigFile(requestedPath string) {
 	} else {
 		targetPath = requestedPath
 		log.Printf("Saving to new file: %s", targetPath)
-		// 保存到新位置时更新当前文件路径和状态栏
-		a.currentFilePath = targetPath
-		a.updateStatusBar(targetPath)
 	}
 	
 	if a.userConfig == nil {
-		dialog.ShowError(fmt.Errorf("没有可保存的配置数据"), a.window)
+		dialog.ShowError(i18n.Errorf("没有可保存的配置数据"), a.window)
 		return
 	}
 	
-	// 使用parser保存配置并生成conf文件
-	if err := a.parser.SaveConfigWithConf(a.userConfig, targetPath); err != nil {
+	outputPaths, err := a.document.writeConfig(targetPath, false)
+	var modified *config.ConfModifiedError
+	if errors.As(err, &modified) {
+		a.showConfEditDialog(a.document, targetPath, modified)
+		return
+	}
+	if err != nil {
 		dialog.ShowError(err, a.window)
 		return
 	}
 	
-	// 生成conf文件路径
-	confPath := strings.TrimSuffix(targetPath, filepath.Ext(targetPath)) + ".conf"
+	a.finishSave(a.document, targetPath, outputPaths)
+}
+
+// finishSave doc保存成功后更新状态并提示生成的文件
+// 保存到新位置时，在文件写入成功后才更新文档的文件路径、状态栏和最近文件，保存失败时文档仍是未保存的
+func (a *App) finishSave(doc *document, targetPath string, outputPaths []string) {
+	overwritten := doc.currentFilePath != ""
+	if !overwritten {
+		doc.currentFilePath = targetPath
+		a.updateDocumentStatusBar(doc, targetPath)
+		a.addRecentFile(targetPath, false)
+	}
+	a.markClean(doc)
 	
 	// 显示成功消息
 	var message string
-	if a.currentFilePath != "" {
-		message = fmt.Sprintf("配置已成功保存并覆盖原文件！\n\nYAML配置: %s\nDHF配置: %s", targetPath, confPath)
+	if overwritten {
+		message = i18n.T("配置已成功保存并覆盖原文件！\n\nYAML配置: %s\nDHF配置: %s", targetPath, strings.Join(outputPaths, "\n         "))
 	} else {
-		message = fmt.Sprintf("配置保存成功！\n\nYAML配置: %s\nDHF配置: %s", targetPath, confPath)
+		message = i18n.T("配置保存成功！\n\nYAML配置: %s\nDHF配置: %s", targetPath, strings.Join(outputPaths, "\n         "))
 	}
 	
-	dialog.ShowInformation("保存成功", message, a.window)
+	dialog.ShowInformation(i18n.T("保存成功"), message, a.window)
 	log.Printf("Successfully saved YAML and generated conf file")
 }
 
+// showRestoreDialog 列出当前文件的历史版本，选择后恢复YAML及对应的conf文件
+func (a *App) showRestoreDialog() {
+	if a.currentFilePath == "" {
+		dialog.ShowInformation(i18n.T("历史版本"), i18n.T("请先打开或保存一个配置文件"), a.window)
+		return
+	}
+	
+	backups := a.parser.ListBackups(a.currentFilePath)
+	if len(backups) == 0 {
+		dialog.ShowInformation(i18n.T("历史版本"), i18n.T("%s 暂无历史版本\n\n每次保存时会自动保留最近%d个版本", 
+			filepath.Base(a.currentFilePath), backupCount), a.window)
+		return
+	}
+	
+	labels := make([]string, len(backups))
+	for i, backup := range backups {
+		labels[i] = fmt.Sprintf("#%d  %s", backup.Index, backup.ModTime.Format("2006-01-02 15:04:05"))
+		if !backup.Complete {
+			labels[i] += "  " + i18n.T("（不完整，无法恢复）")
+		}
+	}
+	
+	selected := -1
+	list := widget.NewList(
+		func() int { return len(labels) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(labels[id])
+		},
+	)
+	list.OnSelected = func(id widget.ListItemID) {
+		selected = id
+	}
+	
+	content := container.NewBorder(
+		widget.NewLabel(i18n.T("选择要恢复的版本（当前版本会先保存为历史版本）：")),
+		nil, nil, nil,
+		list,
+	)
+	
+	restoreDialog := dialog.NewCustomConfirm(i18n.T("恢复历史版本"), i18n.T("恢复"), i18n.T("取消"), content, func(confirmed bool) {
+		if !confirmed || selected < 0 {
+			return
+		}
+		if !backups[selected].Complete {
+			dialog.ShowInformation(i18n.T("恢复历史版本"), i18n.T("该版本保存时部分文件（如conf）还不存在，无法与YAML一起恢复"), a.window)
+			return
+		}
+		
+		filePath := a.currentFilePath
+		if err := a.parser.RestoreBackup(filePath, backups[selected].Index); err != nil {
+			dialog.ShowError(i18n.Errorf("恢复失败: %v", err), a.window)
+			return
+		}
+		log.Printf("Restored %s from backup #%d", filePath, backups[selected].Index)
+		a.openConfigFile(filePath)
+	}, a.window)
+	restoreDialog.Resize(fyne.NewSize(450, 350))
+	restoreDialog.Show()
+}
+
 // generateSchemaFromConfig 从配置文件动态生成schema
 func (a *App) generateSchemaFromConfig(userConfig *models.UserConfig) *models.Schema {
 	schema := &models.Schema{
 		SchemaVersion: "1.0",
-		DisplayName:   "动态配置",
+		DisplayName:   i18n.Text("动态配置"),
 		Sections:      make(map[string]models.ConfigSection),
 	}
 	
@@ -459,9 +654,19 @@ func (a *App) createFieldFromValue(key string, value interface{}) models.ConfigF
 		if a.isEnumValue(v) {
 			field.Type = "select"
 			field.Options = a.getEnumOptions(v)
+		} else if _, isColor := config.ParseColor(v); isColor {
+			field.Type = "color"
 		} else {
 			field.Type = "text"
 		}
+	case []interface{}:
+		// 列表作为多选，已有的各项作为选项
+		field.Type = "flags"
+		for _, item := range v {
+			field.Options = append(field.Options, models.ConfigOption{Value: item, Label: models.NewText(config.FormatValue(item))})
+		}
+	case map[string]interface{}:
+		field.Type = "map"
 	default:
 		field.Type = "text"
 	}
@@ -470,7 +675,7 @@ func (a *App) createFieldFromValue(key string, value interface{}) models.ConfigF
 }
 
 // getSectionDisplayName 获取section的显示名称
-func (a *App) getSectionDisplayName(key string) string {
+func (a *App) getSectionDisplayName(key string) models.Text {
 	nameMap := map[string]string{
 		"basic":      "基础配置",
 		"key_actions": "按键配置",
@@ -480,13 +685,14 @@ func (a *App) getSectionDisplayName(key string) string {
 	}
 	
 	if name, exists := nameMap[key]; exists {
-		return name
+		return i18n.Text(name)
 	}
-	return strings.Title(key) + "配置"
+	title := strings.Title(key)
+	return models.Text{Default: title + "配置", Translations: map[string]string{i18n.English: title}}
 }
 
 // getGroupDisplayName 获取group的显示名称
-func (a *App) getGroupDisplayName(key string) string {
+func (a *App) getGroupDisplayName(key string) models.Text {
 	nameMap := map[string]string{
 		"call_scenario":      "通话场景",
 		"music_scenario":     "音乐场景",
@@ -498,13 +704,13 @@ func (a *App) getGroupDisplayName(key string) string {
 	}
 	
 	if name, exists := nameMap[key]; exists {
-		return name
+		return i18n.Text(name)
 	}
-	return strings.Title(key)
+	return models.NewText(strings.Title(key))
 }
 
 // getFieldDisplayName 获取field的显示名称
-func (a *App) getFieldDisplayName(key string) string {
+func (a *App) getFieldDisplayName(key string) models.Text {
 	nameMap := map[string]string{
 		"ic_model":           "IC型号",
 		"vm_operation":       "VM操作",
@@ -518,9 +724,9 @@ func (a *App) getFieldDisplayName(key string) string {
 	}
 	
 	if name, exists := nameMap[key]; exists {
-		return name
+		return i18n.Text(name)
 	}
-	return strings.Title(strings.ReplaceAll(key, "_", " "))
+	return models.NewText(strings.Title(strings.ReplaceAll(key, "_", " ")))
 }
 
 // isEnumValue 判断是否为枚举值
@@ -539,29 +745,29 @@ func (a *App) getEnumOptions(value string) []models.ConfigOption {
 	// 基于当前值推断可能的选项
 	if strings.HasPrefix(value, "APP_MSG_") {
 		return []models.ConfigOption{
-			{Value: "APP_MSG_NULL", Label: "无操作"},
-			{Value: "APP_MSG_CALL_ANSWER", Label: "接听"},
-			{Value: "APP_MSG_CALL_HANGUP", Label: "挂断"},
-			{Value: "APP_MSG_VOL_UP", Label: "音量+"},
-			{Value: "APP_MSG_VOL_DOWN", Label: "音量-"},
-			{Value: "APP_MSG_MUSIC_PP", Label: "播放/暂停"},
-			{Value: "APP_MSG_MUSIC_NEXT", Label: "下一首"},
-			{Value: "APP_MSG_MUSIC_PREV", Label: "上一首"},
-			{Value: "APP_MSG_OPEN_SIRI", Label: "打开Siri"},
+			{Value: "APP_MSG_NULL", Label: i18n.Text("无操作")},
+			{Value: "APP_MSG_CALL_ANSWER", Label: i18n.Text("接听")},
+			{Value: "APP_MSG_CALL_HANGUP", Label: i18n.Text("挂断")},
+			{Value: "APP_MSG_VOL_UP", Label: i18n.Text("音量+")},
+			{Value: "APP_MSG_VOL_DOWN", Label: i18n.Text("音量-")},
+			{Value: "APP_MSG_MUSIC_PP", Label: i18n.Text("播放/暂停")},
+			{Value: "APP_MSG_MUSIC_NEXT", Label: i18n.Text("下一首")},
+			{Value: "APP_MSG_MUSIC_PREV", Label: i18n.Text("上一首")},
+			{Value: "APP_MSG_OPEN_SIRI", Label: i18n.Text("打开Siri")},
 		}
 	} else if strings.HasPrefix(value, "LED_") {
 		return []models.ConfigOption{
-			{Value: "LED_BLUE_ON", Label: "蓝灯常亮"},
-			{Value: "LED_RED_ON", Label: "红灯常亮"},
-			{Value: "LED_GREEN_ON", Label: "绿灯常亮"},
-			{Value: "LED_BLUE_FAST", Label: "蓝灯快闪"},
-			{Value: "LED_RED_SLOW", Label: "红灯慢闪"},
-			{Value: "LED_OFF", Label: "关闭"},
+			{Value: "LED_BLUE_ON", Label: i18n.Text("蓝灯常亮")},
+			{Value: "LED_RED_ON", Label: i18n.Text("红灯常亮")},
+			{Value: "LED_GREEN_ON", Label: i18n.Text("绿灯常亮")},
+			{Value: "LED_BLUE_FAST", Label: i18n.Text("蓝灯快闪")},
+			{Value: "LED_RED_SLOW", Label: i18n.Text("红灯慢闪")},
+			{Value: "LED_OFF", Label: i18n.Text(i18n.Ctx("灯效", "关闭"))},
 		}
 	}
 	
 	// 默认只提供当前值作为选项
 	return []models.ConfigOption{
-		{Value: value, Label: value},
+		{Value: value, Label: models.NewText(value)},
 	}
 }
\ No newline at end of file
@@ -0,0 +1,233 @@
+package ui
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"configcraft/internal/config"
+	"configcraft/internal/i18n"
+	"configcraft/internal/ui/components"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// batchStatusLabels 批量结果状态的显示文字，显示时再翻译
+var batchStatusLabels = map[string]string{
+	config.BatchPassed:  "✓ 通过",
+	config.BatchWarning: "! 警告",
+	config.BatchFailed:  "✗ 失败",
+}
+
+// showBatchDialog 批量校验并生成一个目录或通配符匹配的所有配置
+func (a *App) showBatchDialog() {
+	zenityDialog := components.NewZenityFileDialog()
+
+	patternEntry := widget.NewEntry()
+	patternEntry.SetPlaceHolder(i18n.T("配置目录，或通配符如 configs/*.yaml"))
+	schemaEntry := widget.NewEntry()
+	schemaEntry.SetPlaceHolder(i18n.T("schema文件"))
+	outputEntry := widget.NewEntry()
+	outputEntry.SetPlaceHolder(i18n.T("留空则输出到各配置文件旁"))
+	validateOnly := widget.NewCheck(i18n.T("只校验，不生成输出"), nil)
+	modeSelect := widget.NewSelect(config.OutputModes, nil)
+	modeSelect.SetSelected(config.OutputFull)
+
+	// 默认使用当前标签页的schema和配置所在目录
+	if a.schemaFilePath != "" {
+		schemaEntry.SetText(a.schemaFilePath)
+	}
+	if a.currentFilePath != "" {
+		patternEntry.SetText(filepath.Dir(a.currentFilePath))
+	}
+
+	browseDir := func(entry *widget.Entry, title string) *widget.Button {
+		return widget.NewButton(i18n.T("浏览"), func() {
+			if dirPath, err := zenityDialog.ShowDirectoryDialog(title); err == nil {
+				entry.SetText(dirPath)
+			}
+		})
+	}
+	browseSchema := widget.NewButton(i18n.T("浏览"), func() {
+		if filePath, err := zenityDialog.ShowOpenDialog(i18n.T("选择Schema文件")); err == nil {
+			schemaEntry.SetText(filePath)
+		}
+	})
+
+	var results []config.BatchResult
+	resultList := widget.NewList(
+		func() int { return len(results) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			result := results[id]
+			obj.(*widget.Label).SetText(i18n.T("%s  %s  （%d个问题）",
+				i18n.T(batchStatusLabels[result.Status()]), result.ConfigPath, len(result.Issues)))
+		},
+	)
+	detailLabel := widget.NewLabel(i18n.T("选择一个结果查看详情"))
+	detailLabel.Wrapping = fyne.TextWrapWord
+	resultList.OnSelected = func(id widget.ListItemID) {
+		detailLabel.SetText(batchResultDetail(results[id]))
+	}
+	summaryLabel := widget.NewLabel("")
+	progress := widget.NewProgressBar()
+	progress.Hide()
+
+	reportFormat := widget.NewSelect(config.ReportFormats, nil)
+	reportFormat.SetSelected(config.ReportText)
+	var exportBtn *widget.Button
+	exportBtn = widget.NewButton(i18n.T("导出报告"), func() {
+		a.exportBatchReport(reportFormat.Selected, schemaEntry.Text, results)
+	})
+	exportBtn.Disable()
+
+	var runBtn *widget.Button
+	runBtn = widget.NewButton(i18n.T("开始"), func() {
+		schemaPath := strings.TrimSpace(schemaEntry.Text)
+		pattern := strings.TrimSpace(patternEntry.Text)
+		if schemaPath == "" || pattern == "" {
+			dialog.ShowInformation(i18n.T("批量生成"), i18n.T("请选择配置目录（或通配符）和schema文件"), a.window)
+			return
+		}
+
+		configPaths, err := config.ExpandConfigPaths([]string{pattern}, schemaPath)
+		if err != nil {
+			dialog.ShowError(err, a.window)
+			return
+		}
+
+		options := config.BatchOptions{
+			OutputDir:    strings.TrimSpace(outputEntry.Text),
+			ValidateOnly: validateOnly.Checked,
+			Mode:         modeSelect.Selected,
+			Backups:      backupCount,
+		}
+
+		runBtn.Disable()
+		exportBtn.Disable()
+		results = nil
+		resultList.UnselectAll()
+		resultList.Refresh()
+		progress.Max = float64(len(configPaths))
+		progress.SetValue(0)
+		progress.Show()
+		summaryLabel.SetText(i18n.T("正在处理%d个配置...", len(configPaths)))
+
+		// 后台只负责生成，结果交回界面goroutine后再更新列表，列表回调读取的results不会被并发修改
+		go func() {
+			batchResults, err := config.RunBatch(schemaPath, configPaths, options, func(done int, result config.BatchResult) {
+				a.runOnUI(func() {
+					progress.SetValue(float64(done))
+				})
+			})
+			a.runOnUI(func() {
+				runBtn.Enable()
+				progress.Hide()
+				if err != nil {
+					summaryLabel.SetText("")
+					dialog.ShowError(i18n.Errorf("批量生成失败: %v", err), a.window)
+					return
+				}
+
+				results = batchResults
+				resultList.Refresh()
+				exportBtn.Enable()
+
+				summary := config.SummarizeBatch(results)
+				summaryLabel.SetText(i18n.T("共%d个配置：通过%d，警告%d，失败%d",
+					summary.Total, summary.Passed, summary.Warnings, summary.Failed))
+				log.Printf("Batch finished: %+v", summary)
+			})
+		}()
+	})
+	runBtn.Importance = widget.HighImportance
+
+	form := widget.NewForm(
+		widget.NewFormItem(i18n.T("配置"), container.NewBorder(nil, nil, nil, browseDir(patternEntry, i18n.T("选择配置目录")), patternEntry)),
+		widget.NewFormItem("Schema", container.NewBorder(nil, nil, nil, browseSchema, schemaEntry)),
+		widget.NewFormItem(i18n.T("输出目录"), container.NewBorder(nil, nil, nil, browseDir(outputEntry, i18n.T("选择输出目录")), outputEntry)),
+		widget.NewFormItem(i18n.T("输出模式"), modeSelect),
+	)
+
+	detailScroll := container.NewVScroll(detailLabel)
+	detailScroll.SetMinSize(fyne.NewSize(0, 120))
+
+	content := container.NewBorder(
+		container.NewVBox(
+			form,
+			container.NewHBox(validateOnly, runBtn),
+			progress,
+			summaryLabel,
+			widget.NewSeparator(),
+		),
+		container.NewVBox(
+			widget.NewSeparator(),
+			detailScroll,
+			container.NewHBox(widget.NewLabel(i18n.T("报告格式")), reportFormat, exportBtn),
+		),
+		nil, nil,
+		resultList,
+	)
+
+	batchDialog := dialog.NewCustom(i18n.T("批量生成"), i18n.T(i18n.Ctx("按钮", "关闭")), content, a.window)
+	batchDialog.Resize(fyne.NewSize(800, 620))
+	batchDialog.Show()
+}
+
+// batchResultDetail 单个配置结果的详细说明
+func batchResultDetail(result config.BatchResult) string {
+	lines := []string{result.ConfigPath}
+	if result.OutputPath != "" {
+		lines = append(lines, i18n.T("输出: ")+result.OutputPath)
+	}
+	if result.Err != nil {
+		lines = append(lines, i18n.T("错误: %v", result.Err))
+	}
+	for _, issue := range result.Issues {
+		prefix := i18n.T("警告")
+		if issue.Severity == config.SeverityError {
+			prefix = i18n.T("错误")
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", prefix, issue))
+	}
+	if result.Err == nil && len(result.Issues) == 0 {
+		lines = append(lines, i18n.T("没有发现问题"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// exportBatchReport 把批量结果按指定格式保存为报告文件
+func (a *App) exportBatchReport(format, schemaPath string, results []config.BatchResult) {
+	extensions := map[string]string{
+		config.ReportText:  ".txt",
+		config.ReportJSON:  ".json",
+		config.ReportJUnit: ".xml",
+	}
+
+	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, a.window)
+			return
+		}
+		if writer == nil {
+			return
+		}
+		defer writer.Close()
+
+		if err := config.WriteBatchReport(writer, format, schemaPath, results); err != nil {
+			dialog.ShowError(i18n.Errorf("无法写入报告: %v", err), a.window)
+			return
+		}
+		log.Printf("Batch report saved: %s", writer.URI().Path())
+	}, a.window)
+
+	saveDialog.SetFileName("configcraft-report" + extensions[format])
+	saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{extensions[format]}))
+	saveDialog.Resize(fyne.NewSize(900, 650))
+	saveDialog.Show()
+}
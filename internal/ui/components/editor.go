@@ -1,13 +1,18 @@
 package components
 
 import (
+	"configcraft/internal/config"
+	"configcraft/internal/i18n"
 	"configcraft/internal/models"
 	"fmt"
+	"image/color"
+	"reflect"
 	"sort"
 	"strconv"
 	"strings"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
@@ -15,36 +20,83 @@ import (
 
 type ConfigEditor struct {
 	container  fyne.CanvasObject
-	content    *fyne.Container
+	content    *fyne.Container // 欢迎提示或当前分组的字段列表
+	list       *virtualList
 	schema     *models.Schema
 	userConfig *models.UserConfig
-	window     fyne.Window // 添加窗口引用以支持弹窗
+	window     fyne.Window   // 添加窗口引用以支持弹窗
+	pathBase   func() string // path字段相对路径的基准目录
+	
+	currentSection string                 // 当前显示的分组ID
+	rows           []editorRow            // 当前分组显示的行
+	rendering      bool                   // 正在构建控件，此时的赋值不算用户修改
+	changeCallback func(fieldPath string) // 用户修改字段值时回调
+	
+	modifiedOnly  bool                      // 只显示覆盖了默认值的字段
+	resetButtons  map[string]*toolButton    // 已构建字段的"恢复默认"按钮，按字段路径
+	fieldControls map[string]fyne.Focusable // 已构建字段的输入控件，按字段路径，用于跳转到字段
+	ledPreviews   map[string]*ledPreview    // 已构建字段的灯效预览，按字段路径
+	
+	modifiedCheck *widget.Check  // 顶部操作栏，切换语言时更新文字
+	resetAllBtn   *widget.Button
 }
 
 func NewConfigEditor() *ConfigEditor {
-	content := container.NewVBox()
-	
-	// 添加简单的欢迎提示
-	welcomeCard := widget.NewCard("Welcome", "Select a configuration group from the left panel to begin editing.", container.NewVBox())
-	content.Add(welcomeCard)
+	// 添加简单的欢迎提示，选择分组后替换为字段列表
+	content := container.NewStack(newWelcomeCard())
+	
+	ce := &ConfigEditor{
+		content:       content,
+		resetButtons:  make(map[string]*toolButton),
+		fieldControls: make(map[string]fyne.Focusable),
+		ledPreviews:   make(map[string]*ledPreview),
+	}
 	
-	// 创建滚动容器
-	scrollContainer := container.NewScroll(content)
-	scrollContainer.SetMinSize(fyne.NewSize(400, 300))
+	// 顶部操作栏：修改过滤和全局恢复默认
+	ce.modifiedCheck = widget.NewCheck(i18n.T("仅显示已修改"), func(checked bool) {
+		ce.modifiedOnly = checked
+		if ce.currentSection != "" {
+			ce.ShowSection(ce.currentSection)
+		}
+	})
+	ce.resetAllBtn = widget.NewButton(i18n.T("全部恢复默认"), func() {
+		ce.confirmReset("", i18n.T("全部配置"))
+	})
+	ce.resetAllBtn.Importance = widget.LowImportance
+	actionBar := container.NewHBox(ce.modifiedCheck, ce.resetAllBtn)
 	
 	// 简化布局，移除多余的标题
-	container := container.NewPadded(scrollContainer)
+	minSize := canvas.NewRectangle(color.Transparent) // 编辑区的最小尺寸
+	minSize.SetMinSize(fyne.NewSize(400, 300))
+	ce.container = container.NewPadded(container.NewBorder(actionBar, nil, nil, nil, container.NewStack(minSize, content)))
 	
-	return &ConfigEditor{
-		container: container,
-		content:   content,
-	}
+	return ce
+}
+
+// newWelcomeCard 尚未选择分组时显示的提示
+func newWelcomeCard() fyne.CanvasObject {
+	return widget.NewCard(i18n.T("欢迎"), i18n.T("从左侧选择一个配置分组开始编辑。"), container.NewVBox())
 }
 
 func (ce *ConfigEditor) Container() fyne.CanvasObject {
 	return ce.container
 }
 
+// Retranslate 切换语言后更新界面文字，重新显示当前分组
+func (ce *ConfigEditor) Retranslate() {
+	ce.modifiedCheck.Text = i18n.T("仅显示已修改")
+	ce.modifiedCheck.Refresh()
+	ce.resetAllBtn.SetText(i18n.T("全部恢复默认"))
+	
+	if ce.currentSection != "" {
+		ce.ShowSection(ce.currentSection)
+		return
+	}
+	ce.list = nil
+	ce.content.Objects = []fyne.CanvasObject{newWelcomeCard()}
+	ce.content.Refresh()
+}
+
 func (ce *ConfigEditor) SetSchema(schema *models.Schema) {
 	ce.schema = schema
 }
@@ -52,112 +104,221 @@ func (ce *ConfigEditor) SetSchema(schema *models.Schema) {
 func (ce *ConfigEditor) SetConfig(config *models.UserConfig) {
 	ce.userConfig = config
 	// 触发当前显示内容的刷新
-	ce.content.Refresh()
+	if ce.list != nil {
+		ce.list.Reset()
+	}
 }
 
 func (ce *ConfigEditor) SetWindow(window fyne.Window) {
 	ce.window = window
 }
 
-func (ce *ConfigEditor) ShowSection(sectionID string) {
-	ce.content.Objects = nil
-	
-	if ce.schema == nil {
-		ce.content.Add(widget.NewLabel("No schema loaded"))
-		ce.content.Refresh()
-		return
+// SetPathBase 设置path字段相对路径的基准目录，每次选择文件时调用
+func (ce *ConfigEditor) SetPathBase(base func() string) {
+	ce.pathBase = base
+}
+
+// SetChangeCallback 设置用户修改字段值时的回调
+func (ce *ConfigEditor) SetChangeCallback(callback func(fieldPath string)) {
+	ce.changeCallback = callback
+}
+
+// CurrentSection 返回当前显示的分组ID
+func (ce *ConfigEditor) CurrentSection() string {
+	return ce.currentSection
+}
+
+// FocusField 让当前显示的字段获取键盘焦点并滚动到可见位置；字段不在当前分组时返回false
+// 开启了"仅显示已修改"而字段被隐藏时，关闭过滤后重新显示
+func (ce *ConfigEditor) FocusField(fieldPath string) bool {
+	index := ce.rowIndex(fieldPath)
+	if index < 0 && ce.modifiedOnly && ce.currentSection != "" {
+		ce.modifiedCheck.SetChecked(false)
+		index = ce.rowIndex(fieldPath)
+	}
+	if index < 0 || ce.list == nil {
+		return false
 	}
 	
-	parts := strings.Split(sectionID, ".")
-	
-	if len(parts) == 1 {
-		ce.showSectionFields(sectionID)
-	} else if len(parts) == 2 {
-		ce.showGroupFields(parts[0], parts[1])
+	ce.list.ScrollTo(index)
+	control, exists := ce.fieldControls[fieldPath]
+	if !exists {
+		return false
+	}
+	if c := fyne.CurrentApp().Driver().CanvasForObject(ce.list); c != nil {
+		c.Focus(control)
 	}
+	return true
+}
+
+// revealNextField 字段获得焦点时把下一行滚动到可见范围，之后再保证该字段可见
+// 列表只构建可见的行，下一行可见时Tab键才能切换到它
+func (ce *ConfigEditor) revealNextField(fieldPath string) func() {
+	return func() {
+		index := ce.rowIndex(fieldPath)
+		if index < 0 || ce.list == nil {
+			return
+		}
+		ce.list.ScrollTo(index + 1)
+		ce.list.ScrollTo(index)
+	}
+}
+
+// rowIndex 字段在当前列表中的行号，不在当前列表中时返回-1
+func (ce *ConfigEditor) rowIndex(fieldPath string) int {
+	for i, row := range ce.rows {
+		if row.fieldPath == fieldPath {
+			return i
+		}
+		for _, cell := range row.cells {
+			if cell == fieldPath {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// ShowSection 显示分组（或子分组）的字段
+// 字段以虚拟列表显示：只为滚动到可见范围内的行创建控件，字段很多时切换分组也不会卡顿
+func (ce *ConfigEditor) ShowSection(sectionID string) {
+	reuse := ce.list != nil && sectionID == ce.currentSection
+	ce.currentSection = sectionID
+	
+	ce.rows = ce.sectionRows(sectionID)
+	ce.resetButtons = make(map[string]*toolButton)
+	ce.fieldControls = make(map[string]fyne.Focusable)
+	for _, preview := range ce.ledPreviews {
+		preview.Stop()
+	}
+	ce.ledPreviews = make(map[string]*ledPreview)
 	
+	// 重新显示同一分组（恢复默认、切换语言后）时保留滚动位置
+	if reuse {
+		ce.list.Reset()
+		return
+	}
+	ce.list = ce.newFieldList()
+	ce.content.Objects = []fyne.CanvasObject{ce.list}
 	ce.content.Refresh()
 }
 
-func (ce *ConfigEditor) showSectionFields(sectionID string) {
-	section, exists := ce.schema.Sections[sectionID]
+// editorRow 字段列表中的一行：标题卡片、字段卡片、表格的一行或提示文字
+type editorRow struct {
+	fieldPath string                   // 字段行的配置路径，其他行为空
+	field     models.ConfigField
+	cells     []string                 // 表格行中各单元格字段的配置路径
+	build     func() fyne.CanvasObject // 非字段行的构建函数
+}
+
+// sectionRows 分组（或子分组）显示的行：标题卡片，然后是按字段键排序的字段
+func (ce *ConfigEditor) sectionRows(sectionID string) []editorRow {
+	message := func(text string) []editorRow {
+		return []editorRow{{build: func() fyne.CanvasObject { return widget.NewLabel(text) }}}
+	}
+	if ce.schema == nil {
+		return message(i18n.T("未加载schema"))
+	}
+	
+	parts := strings.Split(sectionID, ".")
+	section, exists := ce.schema.Sections[parts[0]]
 	if !exists {
-		errorCard := widget.NewCard("Error", "Section not found: "+sectionID, container.NewVBox())
-		ce.content.Add(errorCard)
-		return
+		return []editorRow{{build: func() fyne.CanvasObject {
+			return widget.NewCard(i18n.T("错误"), i18n.T("找不到分组: %s", parts[0]), container.NewVBox())
+		}}}
 	}
 	
-	// 创建现代化的分组标题卡片
-	headerCard := widget.NewCard(section.Name, "Configure the settings below", container.NewVBox())
-	ce.content.Add(headerCard)
+	name := i18n.Local(section.Name)
+	subtitle := i18n.T("在下方修改本分组的配置")
+	fields := section.Fields
+	if len(parts) == 2 {
+		group, exists := section.Groups[parts[1]]
+		if !exists {
+			return []editorRow{{build: func() fyne.CanvasObject {
+				return widget.NewCard(i18n.T("错误"), i18n.T("找不到子分组: %s", parts[1]), container.NewVBox())
+			}}}
+		}
+		name = i18n.Local(group.Name)
+		subtitle = i18n.T("在下方修改本子分组的配置")
+		fields = group.Fields
+		
+		// 按行列排列的子分组显示为表格
+		if group.Layout == config.LayoutMatrix {
+			rows := []editorRow{{build: func() fyne.CanvasObject {
+				return widget.NewCard(name, i18n.T("每个单元格是一个配置项，可以把一行复制到另一行"), ce.createSectionActions(sectionID, name))
+			}}}
+			rows = append(rows, ce.matrixRows(sectionID, group)...)
+			if ce.modifiedOnly && len(rows) == 2 {
+				rows = append(rows, message(i18n.T("本分组没有修改过的配置项"))...)
+			}
+			return rows
+		}
+	}
 	
-	// 重新设计字段布局：每个字段独立成卡片
-	fieldsContainer := container.NewVBox()
+	// 现代化的分组标题卡片
+	rows := []editorRow{{build: func() fyne.CanvasObject {
+		return widget.NewCard(name, subtitle, ce.createSectionActions(sectionID, name))
+	}}}
 	
 	// 按字段键排序以确保一致的显示顺序
-	fieldKeys := make([]string, 0, len(section.Fields))
-	for fieldKey := range section.Fields {
+	fieldKeys := make([]string, 0, len(fields))
+	for fieldKey := range fields {
 		fieldKeys = append(fieldKeys, fieldKey)
 	}
 	sort.Strings(fieldKeys)
 	
 	for _, fieldKey := range fieldKeys {
-		field := section.Fields[fieldKey]
-		fieldWidget := ce.createFieldWidget(sectionID+"."+fieldKey, field)
-		
-		// 每个字段都有自己的卡片，确保明确的视觉分离
-		fieldCard := widget.NewCard("", "", fieldWidget)
-		fieldsContainer.Add(fieldCard)
-		
-		// 添加间距
-		fieldsContainer.Add(widget.NewSeparator())
+		fieldPath := sectionID + "." + fieldKey
+		if ce.isFieldVisible(fieldPath, fields[fieldKey]) {
+			rows = append(rows, editorRow{fieldPath: fieldPath, field: fields[fieldKey]})
+		}
 	}
-	
-	ce.content.Add(fieldsContainer)
+	if ce.modifiedOnly && len(rows) == 1 {
+		rows = append(rows, message(i18n.T("本分组没有修改过的配置项"))...)
+	}
+	return rows
 }
 
-func (ce *ConfigEditor) showGroupFields(sectionID, groupID string) {
-	section, exists := ce.schema.Sections[sectionID]
-	if !exists {
-		errorCard := widget.NewCard("Error", "Section not found: "+sectionID, container.NewVBox())
-		ce.content.Add(errorCard)
-		return
+// newFieldList 创建显示当前行的虚拟列表
+func (ce *ConfigEditor) newFieldList() *virtualList {
+	return newVirtualList(func() int { return len(ce.rows) }, ce.buildRow, ce.forgetRow, ce.estimateRowHeight())
+}
+
+// estimateRowHeight 一个只有标题和输入框的字段卡片的高度，作为尚未显示的行的高度
+func (ce *ConfigEditor) estimateRowHeight() float32 {
+	sample := container.NewPadded(container.NewVBox(widget.NewLabel("A"), widget.NewEntry()))
+	return widget.NewCard("", "", sample).MinSize().Height
+}
+
+// buildRow 构建第id行的控件
+func (ce *ConfigEditor) buildRow(id int) fyne.CanvasObject {
+	ce.rendering = true
+	defer func() { ce.rendering = false }()
+	
+	row := ce.rows[id]
+	if row.build != nil {
+		return row.build()
 	}
-	
-	group, exists := section.Groups[groupID]
-	if !exists {
-		errorCard := widget.NewCard("Error", "Group not found: "+groupID, container.NewVBox())
-		ce.content.Add(errorCard)
+	// 每个字段都有自己的卡片，确保明确的视觉分离
+	return widget.NewCard("", "", ce.createFieldWidget(row.fieldPath, row.field))
+}
+
+// forgetRow 第id行的控件被列表释放后，不再保留它的按钮和输入控件
+func (ce *ConfigEditor) forgetRow(id int) {
+	if id >= len(ce.rows) {
 		return
 	}
-	
-	// 创建现代化的组标题卡片
-	headerCard := widget.NewCard(group.Name, "Configure the group settings below", container.NewVBox())
-	ce.content.Add(headerCard)
-	
-	// 重新设计组字段布局：每个字段独立成卡片
-	fieldsContainer := container.NewVBox()
-	
-	// 按字段键排序以确保一致的显示顺序
-	fieldKeys := make([]string, 0, len(group.Fields))
-	for fieldKey := range group.Fields {
-		fieldKeys = append(fieldKeys, fieldKey)
+	if fieldPath := ce.rows[id].fieldPath; fieldPath != "" {
+		delete(ce.resetButtons, fieldPath)
+		delete(ce.fieldControls, fieldPath)
+		if preview, exists := ce.ledPreviews[fieldPath]; exists {
+			preview.Stop()
+			delete(ce.ledPreviews, fieldPath)
+		}
 	}
-	sort.Strings(fieldKeys)
-	
-	for _, fieldKey := range fieldKeys {
-		field := group.Fields[fieldKey]
-		fieldWidget := ce.createFieldWidget(sectionID+"."+groupID+"."+fieldKey, field)
-		
-		// 每个字段都有自己的卡片，确保明确的视觉分离
-		fieldCard := widget.NewCard("", "", fieldWidget)
-		fieldsContainer.Add(fieldCard)
-		
-		// 添加间距
-		fieldsContainer.Add(widget.NewSeparator())
+	for _, cell := range ce.rows[id].cells {
+		delete(ce.fieldControls, cell)
 	}
-	
-	ce.content.Add(fieldsContainer)
 }
 
 func (ce *ConfigEditor) createFieldWidget(fieldPath string, field models.ConfigField) fyne.CanvasObject {
@@ -168,27 +329,48 @@ func (ce *ConfigEditor) createFieldWidget(fieldPath string, field models.ConfigF
 	headerRow := container.NewBorder(nil, nil, nil, nil)
 	
 	// 主标签
-	titleLabel := widget.NewLabelWithStyle(field.Label, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	label := i18n.Local(field.Label)
+	titleLabel := widget.NewLabelWithStyle(label, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
 	titleLabel.TextStyle.Monospace = false
 	
 	headerContent := container.NewHBox(titleLabel)
 	
 	// 如果有tooltip，添加统一样式的帮助按钮
-	if field.Tooltip != "" {
-		helpBtn := widget.NewButtonWithIcon("", nil, func() {
-			ce.showHelpDialog(field.Label, field.Tooltip)
+	// 标题行的按钮不获取键盘焦点，Tab键只在输入控件之间切换
+	if tooltip := i18n.Local(field.Tooltip); tooltip != "" {
+		helpBtn := newToolButton("💡", func() { // 使用灯泡图标
+			ce.showHelpDialog(label, tooltip)
 		})
-		helpBtn.SetText("💡") // 使用灯泡图标
-		helpBtn.Importance = widget.LowImportance
 		headerContent.Add(helpBtn)
 	}
 	
+	// 备注按钮，记录为什么使用这个值
+	note, hasNote := ce.fieldNote(fieldPath)
+	noteLabel := i18n.T("🗒 备注")
+	if hasNote {
+		noteLabel = i18n.T("🗒 编辑备注")
+	}
+	headerContent.Add(newToolButton(noteLabel, func() {
+		ce.showNoteDialog(fieldPath, label)
+	}))
+	
+	// 恢复默认值按钮，只在值覆盖了默认值时可用
+	resetBtn := newToolButton(i18n.T("↺ 恢复默认"), func() {
+		ce.resetField(fieldPath, field)
+		ce.ShowSection(ce.currentSection)
+	})
+	if !config.IsOverride(field, ce.getValue(fieldPath)) {
+		resetBtn.Disable()
+	}
+	ce.resetButtons[fieldPath] = resetBtn
+	headerContent.Add(resetBtn)
+	
 	headerRow.Objects = []fyne.CanvasObject{headerContent}
 	fieldContainer.Add(headerRow)
 	
 	// === 第二行：描述信息（如果有） ===
-	if field.Description != "" {
-		descText := widget.NewLabelWithStyle("📝 "+field.Description, fyne.TextAlignLeading, fyne.TextStyle{Italic: true})
+	if description := i18n.Local(field.Description); description != "" {
+		descText := widget.NewLabelWithStyle("📝 "+description, fyne.TextAlignLeading, fyne.TextStyle{Italic: true})
 		descText.Wrapping = fyne.TextWrapWord
 		fieldContainer.Add(descText)
 		
@@ -196,6 +378,11 @@ func (ce *ConfigEditor) createFieldWidget(fieldPath string, field models.ConfigF
 		fieldContainer.Add(widget.NewSeparator())
 	}
 	
+	// 备注
+	if hasNote {
+		fieldContainer.Add(createNoteLabel(note))
+	}
+	
 	// === 第三行：控件区域 ===
 	var controlWidget fyne.CanvasObject
 	switch field.Type {
@@ -209,10 +396,30 @@ func (ce *ConfigEditor) createFieldWidget(fieldPath string, field models.ConfigF
 		controlWidget = ce.createNumberWidget(fieldPath, field)
 	case "boolean":
 		controlWidget = ce.createBooleanWidget(fieldPath, field)
+	case "flags":
+		controlWidget = ce.createFlagsWidget(fieldPath, field)
+	case "color":
+		controlWidget = ce.createColorWidget(fieldPath, field)
+	case "path":
+		controlWidget = ce.createPathWidget(fieldPath, field)
+	case "map":
+		controlWidget = ce.createMapWidget(fieldPath, field)
 	default:
 		controlWidget = ce.createTextWidget(fieldPath, field)
 	}
 	
+	// 灯效选项在控件左侧显示动画预览
+	if (field.Type == "select" || field.Type == "combo") && config.HasAnimations(field) {
+		preview := newLedPreview()
+		value := ce.getValue(fieldPath)
+		if value == nil {
+			value = field.Default
+		}
+		preview.SetAnimation(config.OptionAnimation(field, value))
+		ce.ledPreviews[fieldPath] = preview
+		controlWidget = container.NewBorder(nil, nil, container.NewCenter(preview), nil, controlWidget)
+	}
+	
 	fieldContainer.Add(controlWidget)
 	
 	// 使用边框容器添加统一的内边距
@@ -223,14 +430,14 @@ func (ce *ConfigEditor) createFieldWidget(fieldPath string, field models.ConfigF
 func (ce *ConfigEditor) showHelpDialog(title, content string) {
 	// 创建格式化的帮助内容
 	helpContent := container.NewVBox(
-		widget.NewLabelWithStyle("字段说明", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle(i18n.T("字段说明"), fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
 		widget.NewSeparator(),
 		widget.NewLabelWithStyle(title, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 		widget.NewRichTextFromMarkdown(content),
 	)
 	
 	// 创建统一样式的帮助对话框
-	helpDialog := dialog.NewCustom("帮助信息", "关闭", helpContent, ce.window)
+	helpDialog := dialog.NewCustom(i18n.T("帮助信息"), i18n.T(i18n.Ctx("按钮", "关闭")), helpContent, ce.window)
 	helpDialog.Resize(fyne.NewSize(450, 250))
 	helpDialog.Show()
 }
@@ -240,18 +447,20 @@ func (ce *ConfigEditor) createSelectWidget(fieldPath string, field models.Config
 	var values []interface{}
 	
 	for _, option := range field.Options {
-		options = append(options, option.Label)
+		options = append(options, i18n.Local(option.Label))
 		values = append(values, option.Value)
 	}
 	
-	selectWidget := widget.NewSelect(options, func(selected string) {
+	selectWidget := newFieldSelect(options, func(selected string) {
 		for i, option := range field.Options {
-			if option.Label == selected {
+			if i18n.Local(option.Label) == selected {
 				ce.setValue(fieldPath, values[i])
 				break
 			}
 		}
 	})
+	ce.fieldControls[fieldPath] = selectWidget
+	selectWidget.onFocus = ce.revealNextField(fieldPath)
 	
 	if currentValue := ce.getValue(fieldPath); currentValue != nil {
 		for i, value := range values {
@@ -273,10 +482,12 @@ func (ce *ConfigEditor) createSelectWidget(fieldPath string, field models.Config
 }
 
 func (ce *ConfigEditor) createTextWidget(fieldPath string, field models.ConfigField) fyne.CanvasObject {
-	entry := widget.NewEntry()
+	entry := newFieldEntry()
 	entry.OnChanged = func(text string) {
 		ce.setValue(fieldPath, text)
 	}
+	ce.fieldControls[fieldPath] = entry
+	entry.onFocus = ce.revealNextField(fieldPath)
 	
 	if currentValue := ce.getValue(fieldPath); currentValue != nil {
 		if str, ok := currentValue.(string); ok {
@@ -292,12 +503,14 @@ func (ce *ConfigEditor) createTextWidget(fieldPath string, field models.ConfigFi
 }
 
 func (ce *ConfigEditor) createNumberWidget(fieldPath string, field models.ConfigField) fyne.CanvasObject {
-	entry := widget.NewEntry()
+	entry := newFieldEntry()
 	entry.OnChanged = func(text string) {
 		if val, err := strconv.Atoi(text); err == nil {
 			ce.setValue(fieldPath, val)
 		}
 	}
+	ce.fieldControls[fieldPath] = entry
+	entry.onFocus = ce.revealNextField(fieldPath)
 	
 	if currentValue := ce.getValue(fieldPath); currentValue != nil {
 		if num, ok := currentValue.(int); ok {
@@ -313,9 +526,11 @@ func (ce *ConfigEditor) createNumberWidget(fieldPath string, field models.Config
 }
 
 func (ce *ConfigEditor) createBooleanWidget(fieldPath string, field models.ConfigField) fyne.CanvasObject {
-	check := widget.NewCheck("", func(checked bool) {
+	check := newFieldCheck(func(checked bool) {
 		ce.setValue(fieldPath, checked)
 	})
+	ce.fieldControls[fieldPath] = check
+	check.onFocus = ce.revealNextField(fieldPath)
 	
 	if currentValue := ce.getValue(fieldPath); currentValue != nil {
 		if b, ok := currentValue.(bool); ok {
@@ -337,26 +552,29 @@ func (ce *ConfigEditor) createComboWidget(fieldPath string, field models.ConfigF
 	var values []interface{}
 	
 	for _, option := range field.Options {
-		options = append(options, option.Label)
+		options = append(options, i18n.Local(option.Label))
 		values = append(values, option.Value)
 	}
 	
 	// 创建一个容器，包含下拉框和文本输入框
-	entry := widget.NewEntry()
-	if field.Placeholder != "" {
-		entry.PlaceHolder = field.Placeholder
+	entry := newFieldEntry()
+	ce.fieldControls[fieldPath] = entry
+	entry.onFocus = ce.revealNextField(fieldPath)
+	if placeholder := i18n.Local(field.Placeholder); placeholder != "" {
+		entry.PlaceHolder = placeholder
 	}
 	
 	// 创建选择框用于快速选择预设值
-	var selectWidget *widget.Select
-	selectWidget = widget.NewSelect(append([]string{"选择预设值..."}, options...), func(selected string) {
-		if selected == "选择预设值..." {
+	presetHint := i18n.T("选择预设值...")
+	var selectWidget *fieldSelect
+	selectWidget = newFieldSelect(append([]string{presetHint}, options...), func(selected string) {
+		if selected == presetHint {
 			return
 		}
 		
 		// 找到对应的值并设置到输入框
 		for i, option := range field.Options {
-			if option.Label == selected {
+			if i18n.Local(option.Label) == selected {
 				if str, ok := values[i].(string); ok {
 					entry.SetText(str)
 				} else {
@@ -367,8 +585,9 @@ func (ce *ConfigEditor) createComboWidget(fieldPath string, field models.ConfigF
 			}
 		}
 		// 重置选择框显示
-		selectWidget.SetSelected("选择预设值...")
+		selectWidget.SetSelected(presetHint)
 	})
+	selectWidget.onFocus = ce.revealNextField(fieldPath)
 	
 	// 文本输入框变化时更新配置值
 	entry.OnChanged = func(text string) {
@@ -388,7 +607,6 @@ func (ce *ConfigEditor) createComboWidget(fieldPath string, field models.ConfigF
 		entry.SetText(fmt.Sprintf("%v", currentValue))
 	} else if field.Default != nil {
 		entry.SetText(fmt.Sprintf("%v", field.Default))
-		ce.setValue(fieldPath, field.Default)
 	}
 	
 	// 创建简洁的布局：上下结构，没有多余标签
@@ -409,8 +627,124 @@ func (ce *ConfigEditor) getValue(fieldPath string) interface{} {
 }
 
 func (ce *ConfigEditor) setValue(fieldPath string, value interface{}) {
+	// 构建控件时设置初始显示值会触发OnChanged，此时不写入配置；默认值由MaterializeDefaults显式补全
+	if ce.rendering {
+		return
+	}
 	if ce.userConfig == nil {
 		ce.userConfig = &models.UserConfig{Values: make(map[string]interface{})}
 	}
+	if current, exists := ce.userConfig.Values[fieldPath]; exists && reflect.DeepEqual(current, value) {
+		return
+	}
 	ce.userConfig.Values[fieldPath] = value
+	
+	ce.updateResetButton(fieldPath)
+	ce.updateLedPreview(fieldPath)
+	if ce.changeCallback != nil {
+		ce.changeCallback(fieldPath)
+	}
+}
+
+// updateResetButton 用户修改值后更新该字段"恢复默认"按钮的状态
+func (ce *ConfigEditor) updateResetButton(fieldPath string) {
+	resetBtn, exists := ce.resetButtons[fieldPath]
+	if !exists {
+		return
+	}
+	if field, found := config.LookupField(ce.schema, fieldPath); found && config.IsOverride(field, ce.getValue(fieldPath)) {
+		resetBtn.Enable()
+	} else {
+		resetBtn.Disable()
+	}
+}
+
+// updateLedPreview 用户选择或输入新值后切换灯效预览
+func (ce *ConfigEditor) updateLedPreview(fieldPath string) {
+	preview, exists := ce.ledPreviews[fieldPath]
+	if !exists {
+		return
+	}
+	if field, found := config.LookupField(ce.schema, fieldPath); found {
+		preview.SetAnimation(config.OptionAnimation(field, ce.getValue(fieldPath)))
+	}
+}
+
+// isFieldVisible 开启"仅显示已修改"时隐藏等于默认值的字段
+func (ce *ConfigEditor) isFieldVisible(fieldPath string, field models.ConfigField) bool {
+	return !ce.modifiedOnly || config.IsOverride(field, ce.getValue(fieldPath))
+}
+
+// createSectionActions 分组标题卡片中的操作：恢复本组（含子分组）的默认值
+func (ce *ConfigEditor) createSectionActions(nodeID, name string) fyne.CanvasObject {
+	actions := container.NewHBox()
+	
+	resetBtn := widget.NewButton(i18n.T("恢复本组默认值"), func() {
+		ce.confirmReset(nodeID, name)
+	})
+	resetBtn.Importance = widget.LowImportance
+	actions.Add(resetBtn)
+	
+	if ce.modifiedOnly {
+		actions.Add(widget.NewLabel(i18n.T("（仅显示已修改的配置项）")))
+	}
+	return actions
+}
+
+// confirmReset 确认后把nodeID下（为空时为全部）覆盖了默认值的字段恢复为默认值
+func (ce *ConfigEditor) confirmReset(nodeID, name string) {
+	if ce.schema == nil || ce.userConfig == nil || ce.window == nil {
+		return
+	}
+	
+	counts := config.OverrideCounts(ce.schema, ce.userConfig)
+	count := counts[nodeID]
+	if nodeID == "" {
+		for sectionKey := range ce.schema.Sections {
+			count += counts[sectionKey]
+		}
+	}
+	if count == 0 {
+		dialog.ShowInformation(i18n.T("恢复默认值"), i18n.T("%s中没有修改过的配置项", name), ce.window)
+		return
+	}
+	
+	dialog.ShowConfirm(i18n.T("恢复默认值"), i18n.T("将%s中%d个已修改的配置项恢复为默认值？", name, count), func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		ce.resetFields(nodeID)
+	}, ce.window)
+}
+
+// resetFields 把nodeID下（为空时为全部）所有字段恢复为默认值并刷新显示
+func (ce *ConfigEditor) resetFields(nodeID string) {
+	config.ForEachField(ce.schema, func(fieldPath string, field models.ConfigField) {
+		if nodeID != "" && !strings.HasPrefix(fieldPath, nodeID+".") {
+			return
+		}
+		if config.IsOverride(field, ce.getValue(fieldPath)) {
+			ce.resetField(fieldPath, field)
+		}
+	})
+	
+	if ce.currentSection != "" {
+		ce.ShowSection(ce.currentSection)
+	}
+}
+
+// resetField 把字段恢复为schema默认值；没有默认值时删除该配置项
+func (ce *ConfigEditor) resetField(fieldPath string, field models.ConfigField) {
+	if ce.userConfig == nil {
+		return
+	}
+	if field.Default == nil {
+		delete(ce.userConfig.Values, fieldPath)
+	} else {
+		ce.userConfig.Values[fieldPath] = config.CopyValue(field.Default)
+	}
+	
+	if ce.changeCallback != nil {
+		ce.changeCallback(fieldPath)
+	}
 }
\ No newline at end of file
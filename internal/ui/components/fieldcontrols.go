@@ -0,0 +1,81 @@
+package components
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+)
+
+// 字段的输入控件。获得焦点时调用onFocus，编辑区据此把下一个字段滚动到可见范围，
+// 字段列表只构建可见的行，这样Tab键可以一直切换到列表末尾
+
+// fieldEntry 字段的输入框
+// 输入框有焦点时Fyne只把快捷键交给它，这里把输入框不处理的自定义快捷键（Ctrl+S等）转交给窗口
+type fieldEntry struct {
+	widget.Entry
+	onFocus func()
+}
+
+func newFieldEntry() *fieldEntry {
+	entry := &fieldEntry{}
+	entry.ExtendBaseWidget(entry)
+	return entry
+}
+
+func (e *fieldEntry) FocusGained() {
+	e.Entry.FocusGained()
+	if e.onFocus != nil {
+		e.onFocus()
+	}
+}
+
+func (e *fieldEntry) TypedShortcut(shortcut fyne.Shortcut) {
+	if _, custom := shortcut.(*desktop.CustomShortcut); custom {
+		if c, ok := fyne.CurrentApp().Driver().CanvasForObject(e).(fyne.Shortcutable); ok {
+			c.TypedShortcut(shortcut)
+			return
+		}
+	}
+	e.Entry.TypedShortcut(shortcut)
+}
+
+// fieldSelect 字段的下拉框
+type fieldSelect struct {
+	widget.Select
+	onFocus func()
+}
+
+func newFieldSelect(options []string, changed func(string)) *fieldSelect {
+	s := &fieldSelect{}
+	s.Options = options
+	s.OnChanged = changed
+	s.ExtendBaseWidget(s)
+	return s
+}
+
+func (s *fieldSelect) FocusGained() {
+	s.Select.FocusGained()
+	if s.onFocus != nil {
+		s.onFocus()
+	}
+}
+
+// fieldCheck 字段的复选框
+type fieldCheck struct {
+	widget.Check
+	onFocus func()
+}
+
+func newFieldCheck(changed func(bool)) *fieldCheck {
+	c := &fieldCheck{}
+	c.OnChanged = changed
+	c.ExtendBaseWidget(c)
+	return c
+}
+
+func (c *fieldCheck) FocusGained() {
+	c.Check.FocusGained()
+	if c.onFocus != nil {
+		c.onFocus()
+	}
+}
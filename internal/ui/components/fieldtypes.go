@@ -0,0 +1,261 @@
+package components
+
+import (
+	"image/color"
+	"path/filepath"
+	"sort"
+
+	"configcraft/internal/config"
+	"configcraft/internal/i18n"
+	"configcraft/internal/models"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// fieldValue 字段当前的值，没有值时为默认值
+func (ce *ConfigEditor) fieldValue(fieldPath string, field models.ConfigField) interface{} {
+	if value := ce.getValue(fieldPath); value != nil {
+		return value
+	}
+	return field.Default
+}
+
+// createFlagsWidget flags字段：每个选项一个复选框，值为按选项顺序排列的选中值列表
+func (ce *ConfigEditor) createFlagsWidget(fieldPath string, field models.ConfigField) fyne.CanvasObject {
+	selected := make(map[string]bool)
+	if list, ok := config.FlagList(ce.fieldValue(fieldPath, field)); ok {
+		for _, item := range list {
+			selected[config.FormatValue(item)] = true
+		}
+	}
+
+	checks := make([]*fieldCheck, len(field.Options))
+	changed := func(bool) {
+		values := []interface{}{}
+		for i, option := range field.Options {
+			if checks[i].Checked {
+				values = append(values, option.Value)
+			}
+		}
+		ce.setValue(fieldPath, values)
+	}
+
+	box := container.NewVBox()
+	for i, option := range field.Options {
+		check := newFieldCheck(changed)
+		check.Text = i18n.Local(option.Label)
+		check.Checked = selected[config.FormatValue(option.Value)]
+		check.onFocus = ce.revealNextField(fieldPath)
+		checks[i] = check
+		box.Add(check)
+	}
+	if len(checks) > 0 {
+		ce.fieldControls[fieldPath] = checks[0]
+	}
+	return box
+}
+
+// createColorWidget color字段：#RRGGBB输入框、颜色预览和取色对话框
+func (ce *ConfigEditor) createColorWidget(fieldPath string, field models.ConfigField) fyne.CanvasObject {
+	swatch := canvas.NewRectangle(color.Transparent)
+	swatch.SetMinSize(fyne.NewSize(theme.IconInlineSize()*2, theme.IconInlineSize()))
+	swatch.CornerRadius = theme.InputRadiusSize()
+	swatch.StrokeColor = theme.InputBorderColor()
+	swatch.StrokeWidth = 1
+
+	entry := newFieldEntry()
+	entry.SetPlaceHolder("#RRGGBB")
+	entry.OnChanged = func(text string) {
+		swatch.FillColor = color.Transparent
+		if c, ok := config.ParseColor(text); ok {
+			swatch.FillColor = c
+		}
+		swatch.Refresh()
+		ce.setValue(fieldPath, text)
+	}
+	ce.fieldControls[fieldPath] = entry
+	entry.onFocus = ce.revealNextField(fieldPath)
+	if text, ok := ce.fieldValue(fieldPath, field).(string); ok {
+		entry.SetText(text)
+	}
+
+	pick := widget.NewButton(i18n.T("选择颜色…"), func() {
+		if ce.window == nil {
+			return
+		}
+		picker := dialog.NewColorPicker(i18n.T("选择颜色"), i18n.Local(field.Label), func(c color.Color) {
+			entry.SetText(config.ColorText(c))
+		}, ce.window)
+		picker.Advanced = true
+		picker.Show()
+		if c, ok := config.ParseColor(entry.Text); ok {
+			picker.SetColor(c)
+		}
+	})
+	return container.NewBorder(nil, nil, container.NewCenter(swatch), pick, entry)
+}
+
+// createPathWidget path字段：路径输入框和文件选择按钮
+// 选择的文件保存为相对于项目（不属于项目时为配置文件）所在目录的路径，统一使用/分隔
+func (ce *ConfigEditor) createPathWidget(fieldPath string, field models.ConfigField) fyne.CanvasObject {
+	entry := newFieldEntry()
+	if placeholder := i18n.Local(field.Placeholder); placeholder != "" {
+		entry.SetPlaceHolder(placeholder)
+	}
+	entry.OnChanged = func(text string) {
+		ce.setValue(fieldPath, text)
+	}
+	ce.fieldControls[fieldPath] = entry
+	entry.onFocus = ce.revealNextField(fieldPath)
+	if text, ok := ce.fieldValue(fieldPath, field).(string); ok {
+		entry.SetText(text)
+	}
+
+	browse := widget.NewButton(i18n.T("浏览"), func() {
+		var patterns []string
+		for _, ext := range field.Extensions {
+			patterns = append(patterns, "*"+ext)
+		}
+		base := ce.basePath()
+		dir := base
+		if entry.Text != "" {
+			dir = filepath.Dir(resolvePath(base, entry.Text))
+		}
+		if filePath, err := NewZenityFileDialog().ShowFileDialog(i18n.T("选择文件"), dir, patterns); err == nil {
+			entry.SetText(relativePath(base, filePath))
+		}
+	})
+	return container.NewBorder(nil, nil, nil, browse, entry)
+}
+
+// basePath path字段中相对路径的基准目录，未知时为空
+func (ce *ConfigEditor) basePath() string {
+	if ce.pathBase == nil {
+		return ""
+	}
+	return ce.pathBase()
+}
+
+// resolvePath 把配置中的路径转换为绝对路径
+func resolvePath(base, path string) string {
+	path = filepath.FromSlash(path)
+	if filepath.IsAbs(path) || base == "" {
+		return path
+	}
+	return filepath.Join(base, path)
+}
+
+// relativePath 选择的文件相对于base的路径；base为空或无法转换（如在另一个盘符）时保留绝对路径
+func relativePath(base, path string) string {
+	if base == "" {
+		return path
+	}
+	rel, err := filepath.Rel(base, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// mapPair map字段表格中的一行，original为加载时的值，文字未修改时保留其类型（如数字）
+type mapPair struct {
+	key      string
+	value    string
+	original interface{}
+}
+
+// createMapWidget map字段：键值对表格，可以添加和删除行，键为空的行不写入配置
+func (ce *ConfigEditor) createMapWidget(fieldPath string, field models.ConfigField) fyne.CanvasObject {
+	var pairs []*mapPair
+	if m, ok := config.MapValue(ce.fieldValue(fieldPath, field)); ok {
+		keys := make([]string, 0, len(m))
+		for key := range m {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			pairs = append(pairs, &mapPair{key: key, value: config.FormatValue(m[key]), original: m[key]})
+		}
+	}
+
+	write := func() {
+		m := make(map[string]interface{})
+		for _, pair := range pairs {
+			if pair.key == "" {
+				continue
+			}
+			m[pair.key] = pair.value
+			if pair.original != nil && config.FormatValue(pair.original) == pair.value {
+				m[pair.key] = pair.original
+			}
+		}
+		ce.setValue(fieldPath, m)
+	}
+
+	rows := container.NewVBox()
+	addRow := func(pair *mapPair) *fieldEntry {
+		key := newFieldEntry()
+		key.SetPlaceHolder(i18n.T("键"))
+		key.SetText(pair.key)
+		key.OnChanged = func(text string) {
+			pair.key = text
+			write()
+		}
+		value := newFieldEntry()
+		value.SetPlaceHolder(i18n.T("值"))
+		value.SetText(pair.value)
+		value.OnChanged = func(text string) {
+			pair.value = text
+			write()
+		}
+		key.onFocus = ce.revealNextField(fieldPath)
+		value.onFocus = key.onFocus
+
+		var row *fyne.Container
+		remove := newToolButton("✕", func() {
+			for i := range pairs {
+				if pairs[i] == pair {
+					pairs = append(pairs[:i], pairs[i+1:]...)
+					break
+				}
+			}
+			rows.Remove(row)
+			write()
+			ce.relayoutField(fieldPath)
+		})
+		row = container.NewBorder(nil, nil, nil, remove, container.NewGridWithColumns(2, key, value))
+		rows.Add(row)
+		return key
+	}
+	for _, pair := range pairs {
+		if key := addRow(pair); ce.fieldControls[fieldPath] == nil {
+			ce.fieldControls[fieldPath] = key
+		}
+	}
+
+	add := widget.NewButton(i18n.T("+ 添加"), func() {
+		pair := &mapPair{}
+		pairs = append(pairs, pair)
+		key := addRow(pair)
+		ce.relayoutField(fieldPath)
+		if c := fyne.CurrentApp().Driver().CanvasForObject(rows); c != nil {
+			c.Focus(key)
+		}
+	})
+	if ce.fieldControls[fieldPath] == nil {
+		ce.fieldControls[fieldPath] = add
+	}
+	return container.NewVBox(rows, container.NewHBox(add))
+}
+
+// relayoutField 字段控件的高度变化后（如map增删了行）重新布局它所在的行
+func (ce *ConfigEditor) relayoutField(fieldPath string) {
+	if index := ce.rowIndex(fieldPath); index >= 0 && ce.list != nil {
+		ce.list.Remeasure(index)
+	}
+}
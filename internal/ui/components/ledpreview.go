@@ -0,0 +1,125 @@
+package components
+
+import (
+	"image/color"
+	"time"
+
+	"configcraft/internal/config"
+	"configcraft/internal/models"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// ledPreview 灯效字段旁的LED指示灯，按当前选项的灯效动画亮灭
+// 值不是带灯效的选项时显示为熄灭；不再显示时需要调用Stop停止动画
+type ledPreview struct {
+	widget.BaseWidget
+
+	animation *models.LedAnimation
+	started   time.Time
+	ticker    *fyne.Animation
+
+	lit   bool
+	color color.NRGBA
+}
+
+func newLedPreview() *ledPreview {
+	p := &ledPreview{}
+	p.ExtendBaseWidget(p)
+	return p
+}
+
+// SetAnimation 切换到新的灯效并从头播放，nil表示熄灭
+func (p *ledPreview) SetAnimation(animation *models.LedAnimation) {
+	p.Stop()
+	p.animation = animation
+	p.started = time.Now()
+	p.update()
+	if animation == nil || !config.LedAnimated(*animation) {
+		return // 熄灭和常亮不需要动画
+	}
+	p.ticker = fyne.NewAnimation(time.Second, func(float32) { p.update() })
+	p.ticker.RepeatCount = fyne.AnimationRepeatForever
+	p.ticker.Start()
+}
+
+// Stop 停止动画
+func (p *ledPreview) Stop() {
+	if p.ticker != nil {
+		p.ticker.Stop()
+		p.ticker = nil
+	}
+}
+
+// update 按播放的时间计算LED状态，状态变化时刷新
+func (p *ledPreview) update() {
+	var lit bool
+	var c color.NRGBA
+	if p.animation != nil {
+		c, lit = config.LedState(*p.animation, time.Since(p.started))
+	}
+	if lit == p.lit && c == p.color {
+		return
+	}
+	p.lit, p.color = lit, c
+	p.Refresh()
+}
+
+func (p *ledPreview) CreateRenderer() fyne.WidgetRenderer {
+	r := &ledPreviewRenderer{
+		preview: p,
+		glow:    canvas.NewCircle(color.Transparent),
+		led:     canvas.NewCircle(theme.DisabledColor()),
+	}
+	r.Refresh()
+	return r
+}
+
+type ledPreviewRenderer struct {
+	preview *ledPreview
+	glow    *canvas.Circle // 亮时LED周围的光晕
+	led     *canvas.Circle
+}
+
+func (r *ledPreviewRenderer) Layout(size fyne.Size) {
+	diameter := fyne.Min(size.Width, size.Height)
+	center := fyne.NewPos(size.Width/2, size.Height/2)
+	r.glow.Move(center.SubtractXY(diameter/2, diameter/2))
+	r.glow.Resize(fyne.NewSquareSize(diameter))
+
+	inner := diameter * 0.6
+	r.led.Move(center.SubtractXY(inner/2, inner/2))
+	r.led.Resize(fyne.NewSquareSize(inner))
+}
+
+func (r *ledPreviewRenderer) MinSize() fyne.Size {
+	return fyne.NewSquareSize(theme.IconInlineSize() + theme.InnerPadding())
+}
+
+func (r *ledPreviewRenderer) Refresh() {
+	r.led.StrokeColor = theme.InputBorderColor()
+	r.led.StrokeWidth = 1
+	if r.preview.lit {
+		r.led.FillColor = r.preview.color
+		// 光晕随呼吸灯的亮度变化
+		glow := r.preview.color
+		glow.A = uint8(uint16(glow.A) * 0x50 / 0xff)
+		r.glow.FillColor = glow
+	} else {
+		r.led.FillColor = theme.DisabledButtonColor()
+		r.glow.FillColor = color.Transparent
+	}
+	r.glow.Refresh()
+	r.led.Refresh()
+}
+
+func (r *ledPreviewRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.glow, r.led}
+}
+
+func (r *ledPreviewRenderer) Destroy() {
+	r.preview.Stop()
+}
@@ -0,0 +1,188 @@
+package components
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"configcraft/internal/config"
+	"configcraft/internal/i18n"
+	"configcraft/internal/models"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+)
+
+// matrixRows matrix布局的子分组显示的行：列标题，然后表格的每一行
+// 开启"仅显示已修改"时只显示有单元格覆盖了默认值的行
+func (ce *ConfigEditor) matrixRows(groupPath string, group models.ConfigGroup) []editorRow {
+	axes, columns := config.MatrixAxes(group)
+	cells := config.MatrixCells(group)
+
+	rows := []editorRow{{build: func() fyne.CanvasObject {
+		return ce.createMatrixHeader(columns)
+	}}}
+	for _, axis := range axes {
+		axis := axis
+		var paths []string
+		visible := false
+		for _, column := range columns {
+			if fieldKey, exists := cells[axis.Key][column.Key]; exists {
+				path := groupPath + "." + fieldKey
+				paths = append(paths, path)
+				visible = visible || ce.isFieldVisible(path, group.Fields[fieldKey])
+			}
+		}
+		if !visible {
+			continue
+		}
+		rows = append(rows, editorRow{cells: paths, build: func() fyne.CanvasObject {
+			return ce.createMatrixRow(groupPath, group, axis, axes, columns, cells[axis.Key])
+		}})
+	}
+	return rows
+}
+
+// axisLabel 行或列的显示名称，没有名称时为key
+func axisLabel(axis models.MatrixAxis) string {
+	if label := i18n.Local(axis.Label); label != "" {
+		return label
+	}
+	return axis.Key
+}
+
+// createMatrixHeader 列标题，与表格各行使用相同的卡片和网格，列宽一致
+func (ce *ConfigEditor) createMatrixHeader(columns []models.MatrixAxis) fyne.CanvasObject {
+	grid := container.NewGridWithColumns(len(columns) + 1)
+	grid.Add(widget.NewLabel(""))
+	for _, column := range columns {
+		grid.Add(widget.NewLabelWithStyle(axisLabel(column), fyne.TextAlignCenter, fyne.TextStyle{Bold: true}))
+	}
+	return widget.NewCard("", "", container.NewPadded(grid))
+}
+
+// createMatrixRow 表格的一行：行名称和"复制到"按钮，然后每列一个选项下拉框，没有字段的单元格留空
+func (ce *ConfigEditor) createMatrixRow(groupPath string, group models.ConfigGroup, axis models.MatrixAxis, axes, columns []models.MatrixAxis, cells map[string]string) fyne.CanvasObject {
+	grid := container.NewGridWithColumns(len(columns) + 1)
+
+	heading := container.NewVBox(widget.NewLabelWithStyle(axisLabel(axis), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+	if len(axes) > 1 {
+		heading.Add(container.NewHBox(newToolButton(i18n.T("⧉ 复制到…"), func() {
+			ce.showCopyMatrixRow(groupPath, group, axis, axes)
+		})))
+	}
+	grid.Add(heading)
+
+	for _, column := range columns {
+		fieldKey, exists := cells[column.Key]
+		if !exists {
+			grid.Add(container.NewCenter(widget.NewLabel("—")))
+			continue
+		}
+		// 下拉框保持自身高度，在比它高的行中垂直居中
+		cell := ce.createMatrixCell(groupPath+"."+fieldKey, group.Fields[fieldKey])
+		grid.Add(container.NewVBox(layout.NewSpacer(), cell, layout.NewSpacer()))
+	}
+	return widget.NewCard("", "", container.NewPadded(grid))
+}
+
+// createMatrixCell 单元格的选项下拉框；combo字段中输入的自定义值作为额外的一项显示
+func (ce *ConfigEditor) createMatrixCell(fieldPath string, field models.ConfigField) fyne.CanvasObject {
+	var labels []string
+	var values []interface{}
+	for _, option := range field.Options {
+		labels = append(labels, i18n.Local(option.Label))
+		values = append(values, option.Value)
+	}
+
+	current := ce.getValue(fieldPath)
+	if current == nil {
+		current = field.Default
+	}
+	selected := -1
+	for i, value := range values {
+		if current != nil && fmt.Sprintf("%v", value) == fmt.Sprintf("%v", current) {
+			selected = i
+			break
+		}
+	}
+	if selected < 0 && current != nil {
+		labels = append(labels, fmt.Sprintf("%v", current))
+		values = append(values, current)
+		selected = len(values) - 1
+	}
+
+	cell := newFieldSelect(labels, func(label string) {
+		for i := range labels {
+			if labels[i] == label {
+				ce.setValue(fieldPath, values[i])
+				return
+			}
+		}
+	})
+	cell.onFocus = ce.revealNextField(fieldPath)
+	ce.fieldControls[fieldPath] = cell
+	if selected >= 0 {
+		cell.SetSelected(labels[selected])
+	}
+	return cell
+}
+
+// showCopyMatrixRow 选择目标行后把from行各列的值复制过去
+func (ce *ConfigEditor) showCopyMatrixRow(groupPath string, group models.ConfigGroup, from models.MatrixAxis, axes []models.MatrixAxis) {
+	if ce.window == nil {
+		return
+	}
+
+	var targets []models.MatrixAxis
+	var names []string
+	for _, axis := range axes {
+		if axis.Key != from.Key {
+			targets = append(targets, axis)
+			names = append(names, axisLabel(axis))
+		}
+	}
+	target := widget.NewSelect(names, nil)
+	target.SetSelectedIndex(0)
+
+	items := []*widget.FormItem{widget.NewFormItem(i18n.T("复制到"), target)}
+	dialog.ShowForm(i18n.T("复制行：%s", axisLabel(from)), i18n.T("复制"), i18n.T("取消"), items, func(confirmed bool) {
+		if !confirmed || target.SelectedIndex() < 0 {
+			return
+		}
+		ce.copyMatrixRow(groupPath, group, from, targets[target.SelectedIndex()])
+	}, ce.window)
+}
+
+// copyMatrixRow 把from行的值写入to行并刷新显示，目标单元格没有对应选项时提示未复制的单元格
+func (ce *ConfigEditor) copyMatrixRow(groupPath string, group models.ConfigGroup, from, to models.MatrixAxis) {
+	var values map[string]interface{}
+	if ce.userConfig != nil {
+		values = ce.userConfig.Values
+	}
+	assignments, skipped := config.CopyMatrixRow(group, groupPath, values, from.Key, to.Key)
+
+	paths := make([]string, 0, len(assignments))
+	for path := range assignments {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		ce.setValue(path, assignments[path])
+	}
+	if ce.currentSection != "" {
+		ce.ShowSection(ce.currentSection)
+	}
+
+	if len(skipped) > 0 && ce.window != nil {
+		labels := make([]string, len(skipped))
+		for i, path := range skipped {
+			field, _ := config.LookupField(ce.schema, path)
+			labels[i] = i18n.Local(field.Label)
+		}
+		dialog.ShowInformation(i18n.T("复制行"), i18n.T("以下配置项没有对应的选项，未复制：\n%s", strings.Join(labels, "\n")), ce.window)
+	}
+}
@@ -0,0 +1,98 @@
+package components
+
+import (
+	"configcraft/internal/config"
+	"configcraft/internal/i18n"
+	"configcraft/internal/models"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// fieldNote 字段的备注，没有备注时返回false
+func (ce *ConfigEditor) fieldNote(fieldPath string) (models.FieldNote, bool) {
+	if ce.userConfig == nil {
+		return models.FieldNote{}, false
+	}
+	note, exists := ce.userConfig.Notes[fieldPath]
+	return note, exists
+}
+
+// createNoteLabel 字段卡片中显示的备注：备注文字，后面是作者和日期
+func createNoteLabel(note models.FieldNote) fyne.CanvasObject {
+	text := "🗒 " + note.Note
+	if attribution := config.NoteAttribution(note); attribution != "" {
+		text += "  — " + attribution
+	}
+	label := widget.NewLabel(text)
+	label.Wrapping = fyne.TextWrapWord
+	label.Importance = widget.LowImportance
+	return label
+}
+
+// showNoteDialog 编辑字段的备注，备注文字清空后删除备注
+// 新备注的作者和日期为当前用户和今天；修改了文字而没有改作者和日期时同样更新为当前用户和今天
+func (ce *ConfigEditor) showNoteDialog(fieldPath, label string) {
+	if ce.window == nil {
+		return
+	}
+
+	original, exists := ce.fieldNote(fieldPath)
+	fresh := config.NewFieldNote("")
+	if !exists {
+		original.Author, original.Date = fresh.Author, fresh.Date
+	}
+
+	text := widget.NewMultiLineEntry()
+	text.SetPlaceHolder(i18n.T("为什么使用这个值？"))
+	text.Wrapping = fyne.TextWrapWord
+	text.SetMinRowsVisible(4)
+	text.SetText(original.Note)
+	author := widget.NewEntry()
+	author.SetText(original.Author)
+	date := widget.NewEntry()
+	date.SetPlaceHolder(config.NoteDateLayout)
+	date.SetText(original.Date)
+
+	items := []*widget.FormItem{
+		widget.NewFormItem(i18n.T("备注"), text),
+		widget.NewFormItem(i18n.T("作者"), author),
+		widget.NewFormItem(i18n.T("日期"), date),
+	}
+	form := dialog.NewForm(i18n.T("备注：%s", label), i18n.T("确定"), i18n.T("取消"), items, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		note := models.FieldNote{Note: text.Text, Author: author.Text, Date: date.Text}
+		if note.Note != original.Note {
+			if note.Author == original.Author {
+				note.Author = fresh.Author
+			}
+			if note.Date == original.Date {
+				note.Date = fresh.Date
+			}
+		}
+		if note == original {
+			return
+		}
+		if current, _ := ce.fieldNote(fieldPath); note.Note == "" && current.Note == "" {
+			return
+		}
+		ce.setNote(fieldPath, note)
+		ce.ShowSection(ce.currentSection)
+	}, ce.window)
+	form.Resize(fyne.NewSize(480, 320))
+	form.Show()
+}
+
+// setNote 写入字段的备注并通知修改
+func (ce *ConfigEditor) setNote(fieldPath string, note models.FieldNote) {
+	if ce.userConfig == nil {
+		ce.userConfig = &models.UserConfig{Values: make(map[string]interface{})}
+	}
+	config.SetFieldNote(ce.userConfig, fieldPath, note)
+	if ce.changeCallback != nil {
+		ce.changeCallback(fieldPath)
+	}
+}
@@ -1,12 +1,13 @@
 package components
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
-	"strings"
 	
+	"configcraft/internal/i18n"
 	"configcraft/internal/version"
 	
 	"fyne.io/fyne/v2"
@@ -14,15 +15,36 @@ import (
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/storage"
 	"fyne.io/fyne/v2/widget"
+	"github.com/ncruces/zenity"
 )
 
+// RecentFile 最近打开的文件
+type RecentFile struct {
+	Path     string
+	IsSchema bool // true为schema文件，false为配置文件
+}
+
 type Toolbar struct {
-	container *fyne.Container
-	window    fyne.Window
+	container   *fyne.Container
+	window      fyne.Window
+	recentBtn   *widget.Button
+	languageBtn *widget.Button
+	buttonTexts map[*widget.Button]string // 按钮 -> 中文原文，切换语言时重新翻译
+	
+	recentFiles    []RecentFile
+	restoreSession bool // 启动时是否恢复上次会话
 	
-	openCallback func(filePath string)
-	saveCallback func(filePath string)
-	hasOpenFile  func() bool // 检查是否有已打开的文件
+	openCallback           func(filePath string)
+	saveCallback           func(filePath string)
+	restoreCallback        func()             // 恢复历史版本
+	historyCallback        func()             // 查看变更记录
+	copyCallback           func()             // 复制配置值到其他标签页
+	batchCallback          func()             // 批量生成
+	hasOpenFile            func() bool        // 检查是否有已打开的文件
+	clearRecentCallback    func()             // 清空最近文件列表
+	restoreSessionCallback func(enabled bool) // 切换启动时恢复会话
+	languageCallback       func(lang string)  // 切换界面语言
+	settingsCallback       func()             // 打开设置对话框
 }
 
 func (t *Toolbar) SetWindow(window fyne.Window) {
@@ -30,37 +52,132 @@ func (t *Toolbar) SetWindow(window fyne.Window) {
 }
 
 func NewToolbar() *Toolbar {
-	toolbar := &Toolbar{}
+	toolbar := &Toolbar{buttonTexts: make(map[*widget.Button]string)}
 	
 	// 创建OPEN按钮
-	openBtn := widget.NewButton("打开配置", func() {
+	openBtn := toolbar.newButton("打开配置", func() {
 		toolbar.showOpenDialog()
 	})
 	openBtn.Importance = widget.MediumImportance
 	
+	// 创建最近文件按钮
+	toolbar.recentBtn = toolbar.newButton("最近文件", func() {
+		toolbar.showRecentMenu()
+	})
+	toolbar.recentBtn.Importance = widget.MediumImportance
+	
 	// 创建SAVE按钮
-	saveBtn := widget.NewButton("保存配置", func() {
+	saveBtn := toolbar.newButton("保存配置", func() {
 		toolbar.showSaveDialog()
 	})
 	saveBtn.Importance = widget.HighImportance // 高亮保存按钮
 	
+	// 创建历史版本按钮
+	restoreBtn := toolbar.newButton("历史版本", func() {
+		if toolbar.restoreCallback != nil {
+			toolbar.restoreCallback()
+		}
+	})
+	restoreBtn.Importance = widget.LowImportance
+	
+	// 创建变更记录按钮
+	historyBtn := toolbar.newButton("变更记录", func() {
+		if toolbar.historyCallback != nil {
+			toolbar.historyCallback()
+		}
+	})
+	historyBtn.Importance = widget.LowImportance
+	
+	// 创建复制到标签页按钮
+	copyBtn := toolbar.newButton("复制到标签", func() {
+		if toolbar.copyCallback != nil {
+			toolbar.copyCallback()
+		}
+	})
+	copyBtn.Importance = widget.LowImportance
+	
+	// 创建批量生成按钮
+	batchBtn := toolbar.newButton("批量生成", func() {
+		if toolbar.batchCallback != nil {
+			toolbar.batchCallback()
+		}
+	})
+	batchBtn.Importance = widget.LowImportance
+	
+	// 创建语言切换按钮，始终显示当前语言本身的名称
+	toolbar.languageBtn = widget.NewButton("🌐 "+i18n.LanguageNames[i18n.Language()], func() {
+		toolbar.showLanguageMenu()
+	})
+	toolbar.languageBtn.Importance = widget.LowImportance
+	
+	settingsBtn := toolbar.newButton("设置", func() {
+		if toolbar.settingsCallback != nil {
+			toolbar.settingsCallback()
+		}
+	})
+	settingsBtn.Importance = widget.LowImportance
+	
 	// 创建About按钮
-	aboutBtn := widget.NewButton("关于", func() {
+	aboutBtn := toolbar.newButton("关于", func() {
 		toolbar.showAboutDialog()
 	})
 	aboutBtn.Importance = widget.LowImportance
 	
-	// 创建简洁的3按钮工具栏
 	toolbar.container = container.NewHBox(
 		openBtn,
+		toolbar.recentBtn,
 		saveBtn,
+		restoreBtn,
+		historyBtn,
+		copyBtn,
+		batchBtn,
 		widget.NewSeparator(),
+		toolbar.languageBtn,
+		settingsBtn,
 		aboutBtn,
 	)
 	
 	return toolbar
 }
 
+// newButton 创建文字可随语言切换的按钮，text为中文原文
+func (t *Toolbar) newButton(text string, tapped func()) *widget.Button {
+	btn := widget.NewButton(i18n.T(text), tapped)
+	t.buttonTexts[btn] = text
+	return btn
+}
+
+// Retranslate 切换语言后更新按钮文字
+func (t *Toolbar) Retranslate() {
+	for btn, text := range t.buttonTexts {
+		btn.SetText(i18n.T(text))
+	}
+	t.languageBtn.SetText("🌐 " + i18n.LanguageNames[i18n.Language()])
+}
+
+// showLanguageMenu 在按钮下方弹出语言选择菜单
+func (t *Toolbar) showLanguageMenu() {
+	if t.window == nil {
+		return
+	}
+	
+	var items []*fyne.MenuItem
+	for _, lang := range i18n.Languages {
+		lang := lang
+		item := fyne.NewMenuItem(i18n.LanguageNames[lang], func() {
+			if t.languageCallback != nil {
+				t.languageCallback(lang)
+			}
+		})
+		item.Checked = lang == i18n.Language()
+		items = append(items, item)
+	}
+	
+	pos := fyne.CurrentApp().Driver().AbsolutePositionForObject(t.languageBtn)
+	pos = pos.Add(fyne.NewPos(0, t.languageBtn.Size().Height))
+	widget.ShowPopUpMenuAtPosition(fyne.NewMenu("", items...), t.window.Canvas(), pos)
+}
+
 func (t *Toolbar) showAboutDialog() {
 	if t.window == nil {
 		return
@@ -69,11 +186,11 @@ func (t *Toolbar) showAboutDialog() {
 	// 创建现代化的关于对话框内容
 	appTitle := widget.NewLabelWithStyle(version.AppName, fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
 	
-	versionLabel := widget.NewLabelWithStyle("Version "+version.Version, fyne.TextAlignCenter, fyne.TextStyle{})
-	authorLabel := widget.NewLabelWithStyle("Created by Felix", fyne.TextAlignCenter, fyne.TextStyle{})
+	versionLabel := widget.NewLabelWithStyle(i18n.T("版本 %s", version.Version), fyne.TextAlignCenter, fyne.TextStyle{})
+	authorLabel := widget.NewLabelWithStyle(i18n.T("作者：%s", "Felix"), fyne.TextAlignCenter, fyne.TextStyle{})
 	
 	description := widget.NewLabelWithStyle(
-		"Universal Configuration Management Tool\n将复杂配置文件转换为友好的图形界面\n\nA modern visual configuration tool with YAML support",
+		i18n.T("通用配置管理工具\n将复杂配置文件转换为友好的图形界面\n\n支持YAML的现代化可视化配置工具"),
 		fyne.TextAlignCenter, 
 		fyne.TextStyle{},
 	)
@@ -90,11 +207,90 @@ func (t *Toolbar) showAboutDialog() {
 		container.NewPadded(description),
 	)
 	
-	aboutDialog := dialog.NewCustom("About", "Close", content, t.window)
+	aboutDialog := dialog.NewCustom(i18n.T("关于"), i18n.T(i18n.Ctx("按钮", "关闭")), content, t.window)
 	aboutDialog.Resize(fyne.NewSize(450, 300))
 	aboutDialog.Show()
 }
 
+// showRecentMenu 在按钮下方弹出最近文件菜单
+func (t *Toolbar) showRecentMenu() {
+	if t.window == nil {
+		return
+	}
+	
+	var items []*fyne.MenuItem
+	if len(t.recentFiles) == 0 {
+		empty := fyne.NewMenuItem(i18n.T("（暂无最近文件）"), nil)
+		empty.Disabled = true
+		items = append(items, empty)
+	}
+	for _, recent := range t.recentFiles {
+		filePath := recent.Path
+		kind := i18n.T("配置")
+		if recent.IsSchema {
+			kind = "Schema"
+		}
+		label := fmt.Sprintf("[%s] %s  —  %s", kind, filepath.Base(filePath), filepath.Dir(filePath))
+		items = append(items, fyne.NewMenuItem(label, func() {
+			t.openRecentFile(filePath)
+		}))
+	}
+	
+	restoreItem := fyne.NewMenuItem(i18n.T("启动时恢复上次会话"), func() {
+		t.restoreSession = !t.restoreSession
+		if t.restoreSessionCallback != nil {
+			t.restoreSessionCallback(t.restoreSession)
+		}
+	})
+	restoreItem.Checked = t.restoreSession
+	
+	clearItem := fyne.NewMenuItem(i18n.T("清空列表"), func() {
+		if t.clearRecentCallback != nil {
+			t.clearRecentCallback()
+		}
+	})
+	clearItem.Disabled = len(t.recentFiles) == 0
+	
+	items = append(items, fyne.NewMenuItemSeparator(), restoreItem, clearItem)
+	
+	canvas := t.window.Canvas()
+	pos := fyne.CurrentApp().Driver().AbsolutePositionForObject(t.recentBtn)
+	pos = pos.Add(fyne.NewPos(0, t.recentBtn.Size().Height))
+	widget.ShowPopUpMenuAtPosition(fyne.NewMenu("", items...), canvas, pos)
+}
+
+// openRecentFile 打开最近文件，文件已不存在时提示
+func (t *Toolbar) openRecentFile(filePath string) {
+	if _, err := os.Stat(filePath); err != nil {
+		dialog.ShowError(i18n.Errorf("文件不存在或无法访问: %v", err), t.window)
+		return
+	}
+	
+	if t.openCallback != nil {
+		t.openCallback(filePath)
+	}
+}
+
+// SetRecentFiles 更新最近文件列表
+func (t *Toolbar) SetRecentFiles(files []RecentFile) {
+	t.recentFiles = files
+}
+
+// SetRestoreSession 设置"启动时恢复上次会话"选项的当前状态
+func (t *Toolbar) SetRestoreSession(enabled bool) {
+	t.restoreSession = enabled
+}
+
+// Open 与点击"打开"按钮相同，供快捷键使用
+func (t *Toolbar) Open() {
+	t.showOpenDialog()
+}
+
+// Save 与点击"保存"按钮相同，供快捷键使用
+func (t *Toolbar) Save() {
+	t.showSaveDialog()
+}
+
 // showOpenDialog 显示文件打开对话框（使用zenity原生对话框）
 func (t *Toolbar) showOpenDialog() {
 	if t.window == nil {
@@ -105,10 +301,10 @@ func (t *Toolbar) showOpenDialog() {
 	zenityDialog := NewZenityFileDialog()
 	
 	// 显示打开对话框
-	filePath, err := zenityDialog.ShowOpenDialog("选择配置文件")
+	filePath, err := zenityDialog.ShowOpenDialog(i18n.T("选择配置文件"))
 	if err != nil {
 		// 如果是用户取消，不显示错误
-		if !strings.Contains(err.Error(), "用户取消") {
+		if !errors.Is(err, zenity.ErrCanceled) {
 			dialog.ShowError(err, t.window)
 		}
 		return
@@ -122,7 +318,7 @@ func (t *Toolbar) showOpenDialog() {
 
 	// 检查文件是否存在
 	if _, err := os.Stat(filePath); err != nil {
-		dialog.ShowError(fmt.Errorf("文件不存在或无法访问: %v", err), t.window)
+		dialog.ShowError(i18n.Errorf("文件不存在或无法访问: %v", err), t.window)
 		return
 	}
 
@@ -201,6 +397,46 @@ func (t *Toolbar) SetSaveCallback(callback func(filePath string)) {
 	t.saveCallback = callback
 }
 
+// SetRestoreCallback 设置恢复历史版本回调
+func (t *Toolbar) SetRestoreCallback(callback func()) {
+	t.restoreCallback = callback
+}
+
+// SetHistoryCallback 设置查看变更记录回调
+func (t *Toolbar) SetHistoryCallback(callback func()) {
+	t.historyCallback = callback
+}
+
+// SetCopyCallback 设置复制配置值到其他标签页回调
+func (t *Toolbar) SetCopyCallback(callback func()) {
+	t.copyCallback = callback
+}
+
+// SetBatchCallback 设置批量生成回调
+func (t *Toolbar) SetBatchCallback(callback func()) {
+	t.batchCallback = callback
+}
+
+// SetClearRecentCallback 设置清空最近文件列表回调
+func (t *Toolbar) SetClearRecentCallback(callback func()) {
+	t.clearRecentCallback = callback
+}
+
+// SetRestoreSessionCallback 设置切换"启动时恢复上次会话"回调
+func (t *Toolbar) SetRestoreSessionCallback(callback func(enabled bool)) {
+	t.restoreSessionCallback = callback
+}
+
+// SetSettingsCallback 设置打开设置对话框回调
+func (t *Toolbar) SetSettingsCallback(callback func()) {
+	t.settingsCallback = callback
+}
+
+// SetLanguageCallback 设置切换界面语言回调
+func (t *Toolbar) SetLanguageCallback(callback func(lang string)) {
+	t.languageCallback = callback
+}
+
 // SetHasOpenFileCallback 设置检查是否有打开文件的回调
 func (t *Toolbar) SetHasOpenFileCallback(callback func() bool) {
 	t.hasOpenFile = callback
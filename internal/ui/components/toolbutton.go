@@ -0,0 +1,114 @@
+package components
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// toolButton 字段标题行中的小按钮（帮助、恢复默认）
+// 与widget.Button不同，它不获取键盘焦点，Tab键只在字段的输入控件之间切换
+type toolButton struct {
+	widget.BaseWidget
+
+	Text     string
+	OnTapped func()
+
+	disabled bool
+	hovered  bool
+}
+
+func newToolButton(text string, tapped func()) *toolButton {
+	b := &toolButton{Text: text, OnTapped: tapped}
+	b.ExtendBaseWidget(b)
+	return b
+}
+
+func (b *toolButton) Tapped(*fyne.PointEvent) {
+	if !b.disabled && b.OnTapped != nil {
+		b.OnTapped()
+	}
+}
+
+func (b *toolButton) MouseIn(*desktop.MouseEvent) {
+	b.hovered = true
+	b.Refresh()
+}
+
+func (b *toolButton) MouseMoved(*desktop.MouseEvent) {}
+
+func (b *toolButton) MouseOut() {
+	b.hovered = false
+	b.Refresh()
+}
+
+func (b *toolButton) Cursor() desktop.Cursor {
+	if b.disabled {
+		return desktop.DefaultCursor
+	}
+	return desktop.PointerCursor
+}
+
+func (b *toolButton) Enable() {
+	b.disabled = false
+	b.Refresh()
+}
+
+func (b *toolButton) Disable() {
+	b.disabled = true
+	b.Refresh()
+}
+
+func (b *toolButton) Disabled() bool {
+	return b.disabled
+}
+
+func (b *toolButton) CreateRenderer() fyne.WidgetRenderer {
+	r := &toolButtonRenderer{
+		button:     b,
+		background: canvas.NewRectangle(theme.HoverColor()),
+		text:       canvas.NewText(b.Text, theme.ForegroundColor()),
+	}
+	r.Refresh()
+	return r
+}
+
+type toolButtonRenderer struct {
+	button     *toolButton
+	background *canvas.Rectangle
+	text       *canvas.Text
+}
+
+func (r *toolButtonRenderer) Layout(size fyne.Size) {
+	r.background.Resize(size)
+	textSize := r.text.MinSize()
+	r.text.Move(fyne.NewPos((size.Width-textSize.Width)/2, (size.Height-textSize.Height)/2))
+	r.text.Resize(textSize)
+}
+
+func (r *toolButtonRenderer) MinSize() fyne.Size {
+	padding := theme.InnerPadding()
+	return r.text.MinSize().Add(fyne.NewSize(padding*2, padding))
+}
+
+func (r *toolButtonRenderer) Refresh() {
+	r.text.Text = r.button.Text
+	r.text.TextSize = theme.TextSize()
+	r.text.Color = theme.ForegroundColor()
+	if r.button.disabled {
+		r.text.Color = theme.DisabledColor()
+	}
+	r.background.FillColor = theme.HoverColor()
+	r.background.CornerRadius = theme.InputRadiusSize()
+	r.background.Hidden = !r.button.hovered || r.button.disabled
+	r.background.Refresh()
+	r.text.Refresh()
+}
+
+func (r *toolButtonRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.background, r.text}
+}
+
+func (r *toolButtonRenderer) Destroy() {}
@@ -1,13 +1,13 @@
 package components
 
 import (
+	"configcraft/internal/i18n"
 	"configcraft/internal/models"
 	"sort"
 	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
-	"fyne.io/fyne/v2/widget"
 )
 
 // TreeNode 自定义树节点结构
@@ -18,18 +18,20 @@ type TreeNode struct {
 	isExpanded  bool
 	children    []*TreeNode
 	parent      *TreeNode
-	widget      fyne.CanvasObject
+	widget      *treeRow // 该节点的行，第一次显示时创建
 }
 
 // ConfigTree 自定义树形控件，解决Fyne Tree的闪烁问题
 type ConfigTree struct {
 	container         fyne.CanvasObject
+	view             *treeView // 获取键盘焦点的外层控件
 	scroll           *container.Scroll
 	vbox             *fyne.Container
 	schema           *models.Schema
 	selectionCallback func(string)
 	nodes            map[string]*TreeNode
 	selectedNode     *TreeNode
+	overrideCounts   map[string]int // 每个节点中覆盖了默认值的字段数，显示为徽标
 }
 
 func NewConfigTree() *ConfigTree {
@@ -42,7 +44,8 @@ func NewConfigTree() *ConfigTree {
 	scrollContainer := container.NewScroll(vbox)
 	scrollContainer.SetMinSize(fyne.NewSize(250, 300))
 	
-	ct.container = scrollContainer
+	ct.view = newTreeView(ct, scrollContainer)
+	ct.container = ct.view
 	ct.scroll = scrollContainer
 	ct.vbox = vbox
 	
@@ -69,13 +72,12 @@ func (ct *ConfigTree) rebuildTree() {
 	}
 	
 	// 清空现有节点
-	ct.vbox.RemoveAll()
 	ct.nodes = make(map[string]*TreeNode)
 	
 	// 创建根节点
 	rootNode := &TreeNode{
 		id:         "root",
-		name:       "配置分组",
+		name:       i18n.T("配置分组"),
 		isSection:  false,
 		isExpanded: true,
 		children:   make([]*TreeNode, 0),
@@ -113,7 +115,7 @@ func (ct *ConfigTree) rebuildTree() {
 		section := ct.schema.Sections[sectionKey]
 		sectionNode := &TreeNode{
 			id:         sectionKey,
-			name:       section.Name,
+			name:       i18n.Local(section.Name),
 			isSection:  true,
 			isExpanded: false,
 			children:   make([]*TreeNode, 0),
@@ -134,7 +136,7 @@ func (ct *ConfigTree) rebuildTree() {
 			groupID := sectionKey + "." + groupKey
 			groupNode := &TreeNode{
 				id:         groupID,
-				name:       group.Name,
+				name:       i18n.Local(group.Name),
 				isSection:  false,
 				isExpanded: false,
 				children:   make([]*TreeNode, 0),
@@ -149,108 +151,57 @@ func (ct *ConfigTree) rebuildTree() {
 	ct.renderTree()
 }
 
-// renderTree 渲染整个树到界面
+// renderTree 按展开状态更新显示的行
+// 每个节点的行只在第一次显示时创建，之后展开/收起只调整显示哪些行，不重建控件
 func (ct *ConfigTree) renderTree() {
-	ct.vbox.RemoveAll()
-	
+	var rows []fyne.CanvasObject
 	if rootNode, exists := ct.nodes["root"]; exists {
-		ct.renderNodeChildren(rootNode, 0)
+		rows = ct.appendNodeRows(rows, rootNode, 0)
 	}
 	
+	// 一次替换全部行，避免逐行Add时每次都重新布局
+	ct.vbox.Objects = rows
 	ct.vbox.Refresh()
 }
 
-// renderNodeChildren 渲染节点的子节点
-func (ct *ConfigTree) renderNodeChildren(parentNode *TreeNode, depth int) {
+// appendNodeRows 把节点的子节点（展开的节点递归包含其子节点）的行按显示顺序追加到rows
+func (ct *ConfigTree) appendNodeRows(rows []fyne.CanvasObject, parentNode *TreeNode, depth int) []fyne.CanvasObject {
 	for _, child := range parentNode.children {
-		ct.renderNode(child, depth)
+		if child.widget == nil {
+			child.widget = newTreeRow(ct, child, depth)
+		}
+		rows = append(rows, child.widget)
 		
-		// 如果节点展开，递归渲染子节点
+		// 如果节点展开，递归加入子节点
 		if child.isExpanded && len(child.children) > 0 {
-			ct.renderNodeChildren(child, depth+1)
+			rows = ct.appendNodeRows(rows, child, depth+1)
 		}
 	}
+	return rows
 }
 
-// renderNode 渲染单个节点
-func (ct *ConfigTree) renderNode(node *TreeNode, depth int) {
-	// 创建水平容器
-	nodeContainer := container.NewHBox()
-	
-	// 添加紧凑的缩进空间
-	if depth > 0 {
-		indentSpace := widget.NewLabel(strings.Repeat("  ", depth)) // 改为2个空格
-		nodeContainer.Add(indentSpace)
-	}
-	
-	// 创建简约箭头图标
-	if len(node.children) > 0 {
-		// 使用简洁的箭头
-		var expandIcon string
-		if node.isExpanded {
-			expandIcon = "▼" // 下箭头，表示已展开
-		} else {
-			expandIcon = "▶" // 右箭头，表示收缩
-		}
-		
-		// 创建可点击的图标按钮
-		expandButton := widget.NewButton(expandIcon, func() {
-			ct.toggleNode(node)
-		})
-		expandButton.Importance = widget.LowImportance
-		
-		// 设置更小的尺寸
-		expandButton.Resize(fyne.NewSize(20, 20))
-		nodeContainer.Add(expandButton)
-	} else {
-		// 叶子节点留空，保持对齐
-		spacer := widget.NewLabel("")
-		spacer.Resize(fyne.NewSize(20, 20))
-		nodeContainer.Add(spacer)
+// refreshRow 只刷新一个节点的行（选中状态、徽标或名称变化时）
+func (ct *ConfigTree) refreshRow(node *TreeNode) {
+	if node != nil && node.widget != nil {
+		node.widget.Refresh()
 	}
-	
-	// 简洁的节点文本，不添加额外图标
-	nodeText := node.name
-	
-	// 创建节点文本按钮（用于选择）
-	selectButton := widget.NewButton(nodeText, func() {
-		ct.selectNode(node)
-	})
-	
-	// 根据节点类型和选中状态设置样式
-	if ct.selectedNode == node {
-		// 选中节点高亮显示
-		selectButton.Importance = widget.HighImportance
-	} else if node.isSection {
-		// Section节点使用中等重要性
-		selectButton.Importance = widget.MediumImportance
-	} else {
-		// Group节点使用低重要性
-		selectButton.Importance = widget.LowImportance
-	}
-	
-	nodeContainer.Add(selectButton)
-	
-	// 保存widget引用以备更新
-	node.widget = nodeContainer
-	
-	ct.vbox.Add(nodeContainer)
 }
 
 // toggleNode 展开/收缩节点
 func (ct *ConfigTree) toggleNode(node *TreeNode) {
 	node.isExpanded = !node.isExpanded
-	
-	// 重新渲染整个树，但这次没有Fyne Tree的刷新问题
+	ct.refreshRow(node)
 	ct.renderTree()
 }
 
 // selectNode 选择节点
 func (ct *ConfigTree) selectNode(node *TreeNode) {
+	previous := ct.selectedNode
 	ct.selectedNode = node
 	
-	// 重新渲染以更新选择状态
-	ct.renderTree()
+	// 只刷新选中状态变化的两行
+	ct.refreshRow(previous)
+	ct.refreshRow(node)
 	
 	// 触发回调
 	if ct.selectionCallback != nil {
@@ -258,6 +209,172 @@ func (ct *ConfigTree) selectNode(node *TreeNode) {
 	}
 }
 
+// SelectNodeByID 按ID选择节点，并展开其所有上级节点；节点不存在时返回false
+func (ct *ConfigTree) SelectNodeByID(id string) bool {
+	node, exists := ct.nodes[id]
+	if !exists || id == "root" {
+		return false
+	}
+	
+	collapsed := false
+	for parent := node.parent; parent != nil; parent = parent.parent {
+		if !parent.isExpanded {
+			parent.isExpanded = true
+			collapsed = true
+			ct.refreshRow(parent)
+		}
+	}
+	if collapsed {
+		ct.renderTree()
+	}
+	ct.selectAndReveal(node)
+	return true
+}
+
+// SelectedNodeID 返回当前选中节点的ID，未选中时为空
+func (ct *ConfigTree) SelectedNodeID() string {
+	if ct.selectedNode == nil {
+		return ""
+	}
+	return ct.selectedNode.id
+}
+
+// SetOverrideCounts 更新各节点覆盖了默认值的字段数
+// 每次编辑字段都会调用，只刷新数量有变化的行
+func (ct *ConfigTree) SetOverrideCounts(counts map[string]int) {
+	previous := ct.overrideCounts
+	ct.overrideCounts = counts
+	for id, node := range ct.nodes {
+		if previous[id] != counts[id] {
+			ct.refreshRow(node)
+		}
+	}
+}
+
+// Retranslate 切换语言后更新节点名称，保留展开和选中状态
+func (ct *ConfigTree) Retranslate() {
+	if ct.schema == nil {
+		return
+	}
+	
+	for id, node := range ct.nodes {
+		parts := strings.Split(id, ".")
+		section := ct.schema.Sections[parts[0]]
+		switch {
+		case id == "root":
+			node.name = i18n.T("配置分组")
+		case len(parts) == 1:
+			node.name = i18n.Local(section.Name)
+		default:
+			node.name = i18n.Local(section.Groups[parts[1]].Name)
+		}
+		ct.refreshRow(node)
+	}
+}
+
+// focus 让树获取键盘焦点
+func (ct *ConfigTree) focus() {
+	if c := fyne.CurrentApp().Driver().CanvasForObject(ct.view); c != nil {
+		c.Focus(ct.view)
+	}
+}
+
+// refreshSelectedRow 焦点变化后更新选中行的高亮颜色
+func (ct *ConfigTree) refreshSelectedRow() {
+	ct.refreshRow(ct.selectedNode)
+}
+
+// visibleNodes 当前显示的节点，按显示顺序
+func (ct *ConfigTree) visibleNodes() []*TreeNode {
+	var nodes []*TreeNode
+	for _, object := range ct.vbox.Objects {
+		if row, ok := object.(*treeRow); ok {
+			nodes = append(nodes, row.node)
+		}
+	}
+	return nodes
+}
+
+// handleKey 键盘导航：上下移动选中节点，左右收起/展开或移到上级/下级，Enter或空格切换展开状态
+func (ct *ConfigTree) handleKey(key fyne.KeyName) {
+	visible := ct.visibleNodes()
+	if len(visible) == 0 {
+		return
+	}
+	
+	current := -1
+	for i, node := range visible {
+		if node == ct.selectedNode {
+			current = i
+			break
+		}
+	}
+	if current < 0 {
+		// 还没有选中节点时，任意导航键选中第一个
+		switch key {
+		case fyne.KeyUp, fyne.KeyDown, fyne.KeyHome, fyne.KeyEnd, fyne.KeyLeft, fyne.KeyRight:
+			ct.selectAndReveal(visible[0])
+		}
+		return
+	}
+	node := visible[current]
+	
+	switch key {
+	case fyne.KeyUp:
+		if current > 0 {
+			ct.selectAndReveal(visible[current-1])
+		}
+	case fyne.KeyDown:
+		if current < len(visible)-1 {
+			ct.selectAndReveal(visible[current+1])
+		}
+	case fyne.KeyHome:
+		ct.selectAndReveal(visible[0])
+	case fyne.KeyEnd:
+		ct.selectAndReveal(visible[len(visible)-1])
+	case fyne.KeyLeft:
+		if node.isExpanded && len(node.children) > 0 {
+			ct.toggleNode(node)
+		} else if node.parent != nil && node.parent.id != "root" {
+			ct.selectAndReveal(node.parent)
+		}
+	case fyne.KeyRight:
+		if len(node.children) == 0 {
+			return
+		}
+		if !node.isExpanded {
+			ct.toggleNode(node)
+		} else {
+			ct.selectAndReveal(node.children[0])
+		}
+	case fyne.KeyReturn, fyne.KeyEnter, fyne.KeySpace:
+		if len(node.children) > 0 {
+			ct.toggleNode(node)
+		}
+	}
+}
+
+// selectAndReveal 选中节点并滚动到可见位置
+func (ct *ConfigTree) selectAndReveal(node *TreeNode) {
+	ct.selectNode(node)
+	if node.widget == nil {
+		return
+	}
+	
+	top := node.widget.Position().Y
+	bottom := top + node.widget.Size().Height
+	offset := ct.scroll.Offset
+	if top < offset.Y {
+		offset.Y = top
+	} else if bottom > offset.Y+ct.scroll.Size().Height {
+		offset.Y = bottom - ct.scroll.Size().Height
+	}
+	if offset != ct.scroll.Offset {
+		ct.scroll.Offset = offset
+		ct.scroll.Refresh()
+	}
+}
+
 // ForceRefresh 强制刷新 - 重建树结构
 func (ct *ConfigTree) ForceRefresh() {
 	ct.rebuildTree()
@@ -0,0 +1,186 @@
+package components
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// treeView 树的外层控件，作为一个整体获取键盘焦点
+// 方向键移动选中的节点，Enter/空格展开或收起，节点行本身不参与Tab切换
+type treeView struct {
+	widget.BaseWidget
+	tree    *ConfigTree
+	content fyne.CanvasObject
+	focused bool
+}
+
+func newTreeView(tree *ConfigTree, content fyne.CanvasObject) *treeView {
+	v := &treeView{tree: tree, content: content}
+	v.ExtendBaseWidget(v)
+	return v
+}
+
+func (v *treeView) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(v.content)
+}
+
+func (v *treeView) FocusGained() {
+	v.focused = true
+	v.tree.refreshSelectedRow()
+}
+
+func (v *treeView) FocusLost() {
+	v.focused = false
+	v.tree.refreshSelectedRow()
+}
+
+func (v *treeView) TypedRune(rune) {}
+
+func (v *treeView) TypedKey(event *fyne.KeyEvent) {
+	v.tree.handleKey(event.Name)
+}
+
+// Tapped 点击空白处时获取焦点
+func (v *treeView) Tapped(*fyne.PointEvent) {
+	v.tree.focus()
+}
+
+// treeRow 树中的一行：缩进、展开箭头、节点名称和修改数徽标
+// 点击箭头展开或收起，点击其他位置选中节点，双击切换展开状态
+type treeRow struct {
+	widget.BaseWidget
+	tree    *ConfigTree
+	node    *TreeNode
+	depth   int
+	hovered bool
+}
+
+func newTreeRow(tree *ConfigTree, node *TreeNode, depth int) *treeRow {
+	row := &treeRow{tree: tree, node: node, depth: depth}
+	row.ExtendBaseWidget(row)
+	return row
+}
+
+func (row *treeRow) Tapped(event *fyne.PointEvent) {
+	row.tree.focus()
+	if len(row.node.children) > 0 && event.Position.X < row.arrowEnd() {
+		row.tree.toggleNode(row.node)
+		return
+	}
+	row.tree.selectNode(row.node)
+}
+
+func (row *treeRow) DoubleTapped(*fyne.PointEvent) {
+	if len(row.node.children) > 0 {
+		row.tree.toggleNode(row.node)
+	}
+}
+
+func (row *treeRow) MouseIn(*desktop.MouseEvent) {
+	row.hovered = true
+	row.Refresh()
+}
+
+func (row *treeRow) MouseMoved(*desktop.MouseEvent) {}
+
+func (row *treeRow) MouseOut() {
+	row.hovered = false
+	row.Refresh()
+}
+
+// indent 缩进宽度
+func (row *treeRow) indent() float32 {
+	return theme.Padding() + float32(row.depth)*theme.IconInlineSize()
+}
+
+// arrowEnd 展开箭头区域的右边界
+func (row *treeRow) arrowEnd() float32 {
+	return row.indent() + theme.IconInlineSize() + theme.Padding()
+}
+
+func (row *treeRow) CreateRenderer() fyne.WidgetRenderer {
+	r := &treeRowRenderer{
+		row:        row,
+		background: canvas.NewRectangle(theme.SelectionColor()),
+		arrow:      canvas.NewText("", theme.ForegroundColor()),
+		label:      canvas.NewText("", theme.ForegroundColor()),
+	}
+	r.Refresh()
+	return r
+}
+
+type treeRowRenderer struct {
+	row        *treeRow
+	background *canvas.Rectangle
+	arrow      *canvas.Text
+	label      *canvas.Text
+}
+
+func (r *treeRowRenderer) Layout(size fyne.Size) {
+	r.background.Resize(size)
+
+	arrowSize := r.arrow.MinSize()
+	r.arrow.Move(fyne.NewPos(r.row.indent(), (size.Height-arrowSize.Height)/2))
+	r.arrow.Resize(arrowSize)
+
+	labelSize := r.label.MinSize()
+	r.label.Move(fyne.NewPos(r.row.arrowEnd(), (size.Height-labelSize.Height)/2))
+	r.label.Resize(labelSize)
+}
+
+func (r *treeRowRenderer) MinSize() fyne.Size {
+	labelSize := r.label.MinSize()
+	return fyne.NewSize(r.row.arrowEnd()+labelSize.Width+theme.Padding(), labelSize.Height+theme.InnerPadding())
+}
+
+func (r *treeRowRenderer) Refresh() {
+	node := r.row.node
+	tree := r.row.tree
+
+	r.arrow.Text = ""
+	if len(node.children) > 0 {
+		r.arrow.Text = "▶" // 收起
+		if node.isExpanded {
+			r.arrow.Text = "▼" // 已展开
+		}
+	}
+
+	// 有字段覆盖默认值时在名称后显示数量
+	r.label.Text = node.name
+	if count := tree.overrideCounts[node.id]; count > 0 {
+		r.label.Text += fmt.Sprintf("  (%d)", count)
+	}
+	r.label.TextStyle = fyne.TextStyle{Bold: node.isSection}
+
+	for _, text := range []*canvas.Text{r.arrow, r.label} {
+		text.TextSize = theme.TextSize()
+		text.Color = theme.ForegroundColor()
+	}
+
+	// 选中的节点高亮显示，树有键盘焦点时使用焦点颜色
+	switch {
+	case tree.selectedNode == node && tree.view.focused:
+		r.background.FillColor = theme.FocusColor()
+	case tree.selectedNode == node:
+		r.background.FillColor = theme.SelectionColor()
+	default:
+		r.background.FillColor = theme.HoverColor()
+	}
+	r.background.CornerRadius = theme.SelectionRadiusSize()
+	r.background.Hidden = tree.selectedNode != node && !r.row.hovered
+
+	r.background.Refresh()
+	r.arrow.Refresh()
+	r.label.Refresh()
+}
+
+func (r *treeRowRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.background, r.arrow, r.label}
+}
+
+func (r *treeRowRenderer) Destroy() {}
@@ -0,0 +1,227 @@
+package components
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// maxCachedRows 最多保留的已构建行数，超出时释放不在可见范围内的行
+const maxCachedRows = 200
+
+// virtualList 高度不一的行组成的滚动列表，只构建和布局滚动到可见范围内的行
+// 行高在第一次显示该行时测量，尚未显示的行按估计高度占位
+// 与widget.List不同，行没有选中状态，也不会获取键盘焦点
+type virtualList struct {
+	widget.BaseWidget
+
+	length  func() int                     // 行数
+	build   func(id int) fyne.CanvasObject // 构建第id行的控件
+	evicted func(id int)                   // 第id行的控件被释放时调用，可为nil
+
+	estimate float32                   // 未测量的行的高度
+	heights  map[int]float32           // 已测量的行高
+	rows     map[int]fyne.CanvasObject // 已构建的行控件
+	visible  map[int]bool              // 当前显示的行
+
+	scroll  *container.Scroll
+	content *fyne.Container
+}
+
+func newVirtualList(length func() int, build func(id int) fyne.CanvasObject, evicted func(id int), estimate float32) *virtualList {
+	l := &virtualList{length: length, build: build, evicted: evicted, estimate: estimate}
+	l.content = container.New(&virtualLayout{list: l})
+	l.scroll = container.NewVScroll(l.content)
+	l.scroll.OnScrolled = func(fyne.Position) {
+		l.update()
+	}
+	l.Reset()
+	l.ExtendBaseWidget(l)
+	return l
+}
+
+func (l *virtualList) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(l.scroll)
+}
+
+func (l *virtualList) Resize(size fyne.Size) {
+	if l.Size() == size {
+		return
+	}
+	if l.Size().Width != size.Width {
+		l.heights = make(map[int]float32) // 换行的文字在宽度变化后高度不同，重新测量
+	}
+	l.BaseWidget.Resize(size)
+	l.scroll.Resize(size)
+	l.update()
+}
+
+// Reset 丢弃所有已构建的行和测量的行高（行的内容或行数变化后），保留滚动位置
+func (l *virtualList) Reset() {
+	for id := range l.rows {
+		l.evict(id)
+	}
+	l.heights = make(map[int]float32)
+	l.rows = make(map[int]fyne.CanvasObject)
+	l.visible = make(map[int]bool)
+	l.update()
+}
+
+// Row 第id行的控件，第一次使用时构建并缓存
+func (l *virtualList) Row(id int) fyne.CanvasObject {
+	if row, exists := l.rows[id]; exists {
+		return row
+	}
+	row := l.build(id)
+	l.rows[id] = row
+	return row
+}
+
+// ScrollTo 滚动到第id行完整可见的最近位置
+func (l *virtualList) ScrollTo(id int) {
+	if id < 0 || id >= l.length() {
+		return
+	}
+	// 新显示的行测量后，前面的行高变化会让目标行移动，重新计算直到位置稳定
+	for attempt := 0; attempt < 3; attempt++ {
+		l.measure(id)
+		top := l.offsetOf(id)
+		bottom := top + l.heightOf(id)
+
+		offset := l.scroll.Offset.Y
+		if bottom > offset+l.scroll.Size().Height {
+			offset = bottom - l.scroll.Size().Height
+		}
+		if top < offset {
+			offset = top
+		}
+		if offset == l.scroll.Offset.Y && l.visible[id] {
+			return
+		}
+		l.scroll.Offset.Y = offset
+		l.update()
+	}
+}
+
+// Remeasure 第id行的内容改变了高度（如增删了表格行）后重新测量并布局
+func (l *virtualList) Remeasure(id int) {
+	delete(l.heights, id)
+	l.update()
+}
+
+// measure 构建第id行并记录它在当前宽度下的实际高度
+func (l *virtualList) measure(id int) float32 {
+	if height, measured := l.heights[id]; measured {
+		return height
+	}
+	row := l.Row(id)
+	// 自动换行的文字按控件当前的宽度计算高度，先按列表宽度布局一次
+	row.Resize(fyne.NewSize(l.scroll.Size().Width, row.MinSize().Height))
+	height := row.MinSize().Height
+	l.heights[id] = height
+	return height
+}
+
+func (l *virtualList) heightOf(id int) float32 {
+	if height, measured := l.heights[id]; measured {
+		return height
+	}
+	return l.estimate
+}
+
+// offsetOf 第id行顶部的位置
+func (l *virtualList) offsetOf(id int) float32 {
+	var y float32
+	for i := 0; i < id; i++ {
+		y += l.heightOf(i) + theme.Padding()
+	}
+	return y
+}
+
+// totalHeight 所有行（未测量的按估计高度）的总高度
+func (l *virtualList) totalHeight() float32 {
+	length := l.length()
+	if length == 0 {
+		return 0
+	}
+	return l.offsetOf(length) - theme.Padding()
+}
+
+// update 构建并显示与可见范围相交的行，测量新显示的行的高度
+func (l *virtualList) update() {
+	if l.heights == nil {
+		return // 尚未初始化
+	}
+	top := l.scroll.Offset.Y
+	bottom := top + fyne.Max(l.scroll.Size().Height, l.estimate)
+	padding := theme.Padding()
+
+	visible := make(map[int]bool)
+	var objects []fyne.CanvasObject
+	y := float32(0)
+	for id, length := 0, l.length(); id < length && y <= bottom; id++ {
+		height := l.heightOf(id)
+		if y+height >= top {
+			height = l.measure(id)
+			visible[id] = true
+			objects = append(objects, l.rows[id])
+		}
+		y += height + padding
+	}
+
+	l.visible = visible
+	l.trim()
+	l.content.Objects = objects
+	l.content.Refresh()
+	l.scroll.Refresh()
+}
+
+// trim 缓存的行过多时释放当前没有显示的行
+func (l *virtualList) trim() {
+	if len(l.rows) <= maxCachedRows {
+		return
+	}
+	for id := range l.rows {
+		if !l.visible[id] {
+			l.evict(id)
+		}
+	}
+}
+
+func (l *virtualList) evict(id int) {
+	delete(l.rows, id)
+	if l.evicted != nil {
+		l.evicted(id)
+	}
+}
+
+// virtualLayout 按行号把显示的行放到各自的位置，最小高度为所有行的总高度
+type virtualLayout struct {
+	list *virtualList
+}
+
+func (v *virtualLayout) Layout(_ []fyne.CanvasObject, size fyne.Size) {
+	l := v.list
+	padding := theme.Padding()
+	y := float32(0)
+	placed := 0
+	for id, length := 0, l.length(); id < length && placed < len(l.visible); id++ {
+		height := l.heightOf(id)
+		if l.visible[id] {
+			row := l.rows[id]
+			row.Move(fyne.NewPos(0, y))
+			row.Resize(fyne.NewSize(size.Width, height))
+			placed++
+		}
+		y += height + padding
+	}
+}
+
+func (v *virtualLayout) MinSize([]fyne.CanvasObject) fyne.Size {
+	var width float32
+	for id := range v.list.visible {
+		width = fyne.Max(width, v.list.rows[id].MinSize().Width)
+	}
+	return fyne.NewSize(width, v.list.totalHeight())
+}
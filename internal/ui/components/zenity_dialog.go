@@ -1,11 +1,12 @@
 package components
 
 import (
-	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"configcraft/internal/i18n"
+
 	"github.com/ncruces/zenity"
 )
 
@@ -24,7 +25,7 @@ func (zfd *ZenityFileDialog) ShowOpenDialog(title string) (string, error) {
 	options := []zenity.Option{
 		zenity.Title(title),
 		zenity.FileFilter{
-			Name:     "YAML配置文件",
+			Name:     i18n.T("YAML配置文件"),
 			Patterns: []string{"*.yaml", "*.yml"},
 		},
 	}
@@ -36,9 +37,9 @@ func (zfd *ZenityFileDialog) ShowOpenDialog(title string) (string, error) {
 	filePath, err := zenity.SelectFile(options...)
 	if err != nil {
 		if err == zenity.ErrCanceled {
-			return "", fmt.Errorf("用户取消了文件选择")
+			return "", i18n.Errorf("用户取消了文件选择: %w", err)
 		}
-		return "", fmt.Errorf("文件对话框错误: %v", err)
+		return "", i18n.Errorf("文件对话框错误: %v", err)
 	}
 	
 	// 规范化文件路径 - 确保使用正确的路径分隔符
@@ -47,6 +48,56 @@ func (zfd *ZenityFileDialog) ShowOpenDialog(title string) (string, error) {
 	return filePath, nil
 }
 
+// ShowFontDialog 显示字体文件选择对话框
+func (zfd *ZenityFileDialog) ShowFontDialog(title string) (string, error) {
+	filePath, err := zenity.SelectFile(zenity.Title(title), zenity.FileFilter{
+		Name:     i18n.T("字体文件"),
+		Patterns: []string{"*.ttf", "*.otf", "*.ttc"},
+	})
+	if err != nil {
+		if err == zenity.ErrCanceled {
+			return "", i18n.Errorf("用户取消了文件选择: %w", err)
+		}
+		return "", i18n.Errorf("文件对话框错误: %v", err)
+	}
+
+	return filepath.Clean(filePath), nil
+}
+
+// ShowFileDialog 显示任意文件的选择对话框，从dir目录开始；patterns不为空时只显示匹配的文件，如"*.bin"
+func (zfd *ZenityFileDialog) ShowFileDialog(title, dir string, patterns []string) (string, error) {
+	options := []zenity.Option{zenity.Title(title)}
+	if len(patterns) > 0 {
+		options = append(options, zenity.FileFilter{Name: strings.Join(patterns, " "), Patterns: patterns})
+	}
+	if dir != "" {
+		options = append(options, zenity.Filename(dir+string(filepath.Separator)))
+	}
+
+	filePath, err := zenity.SelectFile(options...)
+	if err != nil {
+		if err == zenity.ErrCanceled {
+			return "", i18n.Errorf("用户取消了文件选择: %w", err)
+		}
+		return "", i18n.Errorf("文件对话框错误: %v", err)
+	}
+
+	return filepath.Clean(filePath), nil
+}
+
+// ShowDirectoryDialog 显示目录选择对话框
+func (zfd *ZenityFileDialog) ShowDirectoryDialog(title string) (string, error) {
+	dirPath, err := zenity.SelectFile(zenity.Title(title), zenity.Directory())
+	if err != nil {
+		if err == zenity.ErrCanceled {
+			return "", i18n.Errorf("用户取消了目录选择: %w", err)
+		}
+		return "", i18n.Errorf("目录对话框错误: %v", err)
+	}
+	
+	return filepath.Clean(dirPath), nil
+}
+
 // ShowSaveDialog 显示文件保存对话框
 func (zfd *ZenityFileDialog) ShowSaveDialog(title, defaultName string) (string, error) {
 	// 获取当前工作目录
@@ -63,7 +114,7 @@ func (zfd *ZenityFileDialog) ShowSaveDialog(title, defaultName string) (string,
 		zenity.Title(title),
 		zenity.ConfirmOverwrite(),
 		zenity.FileFilter{
-			Name:     "YAML配置文件",
+			Name:     i18n.T("YAML配置文件"),
 			Patterns: []string{"*.yaml", "*.yml"},
 		},
 		zenity.Filename(defaultPath), // 设置默认文件路径
@@ -73,9 +124,9 @@ func (zfd *ZenityFileDialog) ShowSaveDialog(title, defaultName string) (string,
 	filePath, err := zenity.SelectFileSave(options...)
 	if err != nil {
 		if err == zenity.ErrCanceled {
-			return "", fmt.Errorf("用户取消了文件选择")
+			return "", i18n.Errorf("用户取消了文件选择: %w", err)
 		}
-		return "", fmt.Errorf("文件对话框错误: %v", err)
+		return "", i18n.Errorf("文件对话框错误: %v", err)
 	}
 	
 	// 规范化文件路径 - 确保使用正确的路径分隔符
@@ -88,7 +139,7 @@ func (zfd *ZenityFileDialog) ShowSaveDialog(title, defaultName string) (string,
 func (zfd *ZenityFileDialog) ValidateYAMLFile(filePath string) error {
 	ext := strings.ToLower(filepath.Ext(filePath))
 	if ext != ".yaml" && ext != ".yml" {
-		return fmt.Errorf("请选择YAML格式文件（.yaml或.yml）")
+		return i18n.Errorf("请选择YAML格式文件（.yaml或.yml）")
 	}
 	return nil
 }
@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"configcraft/internal/config"
+	"configcraft/internal/i18n"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// showConfEditDialog 要覆盖的conf文件在上次生成后被手工修改，让用户选择导回、覆盖或取消保存
+// 对话框回调时活动标签页可能已经切换，各按钮始终作用于正在保存的doc
+func (a *App) showConfEditDialog(doc *document, targetPath string, modified *config.ConfModifiedError) {
+	message := widget.NewLabel(i18n.T(
+		"%s 在上次生成后被手工修改了%d项，直接保存会丢失这些修改。\n\n"+
+			"• 导回并保存：把conf中的修改写入YAML配置后再保存\n"+
+			"• 覆盖：丢弃conf中的修改\n"+
+			"• 取消：不保存任何文件",
+		filepath.Base(modified.Path), len(modified.Edits)))
+	message.Wrapping = fyne.TextWrapWord
+
+	editList := widget.NewList(
+		func() int { return len(modified.Edits) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(confEditText(modified.Edits[id]))
+		},
+	)
+	content := container.NewBorder(message, nil, nil, nil, editList)
+
+	editDialog := dialog.NewCustomWithoutButtons(i18n.T("conf文件已被手工修改"), content, a.window)
+
+	importBtn := widget.NewButton(i18n.T("导回并保存"), func() {
+		editDialog.Hide()
+		a.importConfEdits(doc, modified.Edits)
+		a.overwriteConfig(doc, targetPath)
+	})
+	importBtn.Importance = widget.HighImportance
+
+	overwriteBtn := widget.NewButton(i18n.T("覆盖"), func() {
+		editDialog.Hide()
+		a.overwriteConfig(doc, targetPath)
+	})
+
+	cancelBtn := widget.NewButton(i18n.T("取消"), func() {
+		editDialog.Hide()
+		a.setDocumentStatus(doc, i18n.T("已取消保存: %s 中有手工修改", filepath.Base(modified.Path)))
+	})
+
+	editDialog.SetButtons([]fyne.CanvasObject{cancelBtn, overwriteBtn, importBtn})
+	editDialog.Resize(fyne.NewSize(620, 420))
+	editDialog.Show()
+}
+
+// confEditText 一项手工修改的显示文字
+func confEditText(edit config.ConfEdit) string {
+	switch {
+	case edit.Removed:
+		return i18n.T("%s  已删除（原值 %s）", edit.Key, edit.Generated)
+	case edit.Generated == "":
+		return i18n.T("%s  新增 = %s", edit.Key, edit.Current)
+	}
+	return fmt.Sprintf("%s  %s → %s", edit.Key, edit.Generated, edit.Current)
+}
+
+// importConfEdits 把conf中的手工修改写入doc的配置并刷新界面
+func (a *App) importConfEdits(doc *document, edits []config.ConfEdit) {
+	config.ApplyConfEdits(doc.userConfig, edits)
+	for _, edit := range edits {
+		doc.localEdits[edit.Path] = true
+	}
+
+	if doc.schemaFilePath == "" {
+		// 未绑定schema时，schema由配置内容动态生成，导回的新配置项需要出现在界面上
+		doc.schema = a.generateSchemaFromConfig(doc.userConfig)
+		doc.editor.SetSchema(doc.schema)
+	}
+	doc.editor.SetConfig(doc.userConfig)
+	doc.refreshTree()
+	doc.showCurrentSection()
+	log.Printf("Imported %d hand edits from conf file", len(edits))
+}
+
+// overwriteConfig 保存doc的YAML并重新生成输出文件，不再检查conf中的手工修改
+func (a *App) overwriteConfig(doc *document, targetPath string) {
+	outputPaths, err := doc.writeConfig(targetPath, true)
+	if err != nil {
+		dialog.ShowError(err, a.window)
+		return
+	}
+	a.finishSave(doc, targetPath, outputPaths)
+}
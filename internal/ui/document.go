@@ -0,0 +1,340 @@
+package ui
+
+import (
+	"path/filepath"
+
+	"configcraft/internal/config"
+	"configcraft/internal/i18n"
+	"configcraft/internal/models"
+	"configcraft/internal/ui/components"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// document 一个标签页中打开的配置
+// 每个标签页拥有独立的parser、schema、配置、树/编辑器状态、修改记录和状态栏文字
+type document struct {
+	parser     *config.Parser
+	schema     *models.Schema
+	userConfig *models.UserConfig
+
+	tree         *components.ConfigTree
+	editor       *components.ConfigEditor
+	groupsTitle  *widget.Label // 左侧分组导航的标题
+	optionsTitle *widget.Label // 右侧配置选项的标题
+	mainSplit    *container.Split
+	tab          *container.TabItem
+
+	currentFilePath string            // 记录当前打开的文件路径
+	schemaFilePath  string            // 当前使用的schema文件路径（动态生成的schema为空）
+	status          string            // 状态栏文字，切换标签时恢复
+	fileHashes      map[string]string // 最近一次加载/保存时的文件摘要
+	localEdits      map[string]bool   // 自上次加载/保存以来用户修改过的字段
+
+	project       *models.Project       // 从项目打开时所属的项目
+	projectConfig *models.ProjectConfig // 在项目中对应的配置，决定保存时的输出位置
+}
+
+// newDocument 创建一个空白文档及其标签页内容
+func (a *App) newDocument() *document {
+	parser := config.NewParser()
+	parser.SetBackupCount(backupCount)
+
+	doc := &document{
+		parser:     parser,
+		tree:       components.NewConfigTree(),
+		editor:     components.NewConfigEditor(),
+		status:     i18n.T("请打开配置文件..."),
+		fileHashes: make(map[string]string),
+		localEdits: make(map[string]bool),
+	}
+	doc.editor.SetWindow(a.window)
+	doc.editor.SetPathBase(doc.pathBase)
+
+	doc.tree.SetSelectionCallback(func(nodeID string) {
+		doc.editor.ShowSection(nodeID)
+	})
+	doc.editor.SetChangeCallback(func(fieldPath string) {
+		doc.localEdits[fieldPath] = true
+		a.refreshTabTitle(doc)
+		doc.refreshBadges()
+	})
+
+	// 左侧区域：配置分组导航
+	doc.groupsTitle = widget.NewLabelWithStyle(i18n.T("配置组"), fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+	leftPanel := container.NewBorder(
+		container.NewVBox(
+			doc.groupsTitle,
+			widget.NewSeparator(),
+		),
+		nil, nil, nil,
+		doc.tree.Container(),
+	)
+
+	// 右侧区域：详细配置选项
+	doc.optionsTitle = widget.NewLabelWithStyle(i18n.T("配置选项"), fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+	rightPanel := container.NewBorder(
+		container.NewVBox(
+			doc.optionsTitle,
+			widget.NewSeparator(),
+		),
+		nil, nil, nil,
+		doc.editor.Container(),
+	)
+
+	// 创建主分割面板
+	doc.mainSplit = container.NewHSplit(leftPanel, rightPanel)
+	doc.mainSplit.SetOffset(0.3) // 左侧30%，右侧70%
+
+	doc.tab = container.NewTabItem(doc.title(), doc.mainSplit)
+	a.documents = append(a.documents, doc)
+
+	return doc
+}
+
+// retranslate 切换界面语言后更新文档中的文字
+func (d *document) retranslate() {
+	d.groupsTitle.SetText(i18n.T("配置组"))
+	d.optionsTitle.SetText(i18n.T("配置选项"))
+	d.tree.Retranslate()
+	d.editor.Retranslate()
+}
+
+// pathBase path字段相对路径的基准目录：项目所在目录，不属于项目时为配置文件所在目录
+func (d *document) pathBase() string {
+	if d.project != nil {
+		return filepath.Dir(d.project.FilePath)
+	}
+	if d.currentFilePath != "" {
+		return filepath.Dir(d.currentFilePath)
+	}
+	return ""
+}
+
+// generatorSettings 文档的生成设置：属于项目时使用项目中该配置的设置，否则为默认设置（conf格式，输出所有值）
+func (d *document) generatorSettings() models.GeneratorSettings {
+	if d.projectConfig != nil {
+		return d.projectConfig.Generator
+	}
+	return models.GeneratorSettings{}
+}
+
+// writeConfig 把文档保存到targetPath并生成输出文件，返回生成的输出文件
+// 项目中的配置生成到项目指定的输出位置，其他配置生成同名conf文件；
+// overwrite为false时conf输出在上次生成后被手工修改过则不写入，返回*config.ConfModifiedError
+func (d *document) writeConfig(targetPath string, overwrite bool) ([]string, error) {
+	if d.projectConfig != nil && absPath(d.projectConfig.Path) == absPath(targetPath) {
+		save := d.parser.SaveConfigWithOutputs
+		if overwrite {
+			save = d.parser.OverwriteConfigWithOutputs
+		}
+		if err := save(d.userConfig, targetPath, d.projectConfig.Outputs, d.projectConfig.Generator); err != nil {
+			return nil, err
+		}
+		outputPaths := make([]string, len(d.projectConfig.Outputs))
+		for i, output := range d.projectConfig.Outputs {
+			outputPaths[i] = output.Path
+		}
+		return outputPaths, nil
+	}
+
+	save := d.parser.SaveConfigWithConf
+	if overwrite {
+		save = d.parser.OverwriteConfigWithConf
+	}
+	if err := save(d.userConfig, targetPath, d.generatorSettings().Mode); err != nil {
+		return nil, err
+	}
+	return []string{config.ConfPathFor(targetPath)}, nil
+}
+
+// isEmpty 文档是否还没有打开任何文件
+func (d *document) isEmpty() bool {
+	return d.schema == nil && d.userConfig == nil
+}
+
+// isDirty 文档是否有未保存的修改
+func (d *document) isDirty() bool {
+	return len(d.localEdits) > 0
+}
+
+// title 标签页标题，有未保存修改时以*开头
+func (d *document) title() string {
+	var name string
+	switch {
+	case d.projectConfig != nil:
+		name = d.projectConfig.Name
+	case d.currentFilePath != "":
+		name = filepath.Base(d.currentFilePath)
+	case d.schemaFilePath != "":
+		name = i18n.T("新配置 (%s)", filepath.Base(d.schemaFilePath))
+	case d.userConfig != nil:
+		name = i18n.T("新配置")
+	default:
+		name = i18n.T("未命名")
+	}
+
+	if d.isDirty() {
+		return "*" + name
+	}
+	return name
+}
+
+// refreshTree 按schema重建分组导航
+func (d *document) refreshTree() {
+	if d.schema != nil {
+		d.tree.LoadSchema(d.schema)
+		d.refreshBadges()
+	}
+}
+
+// showCurrentSection 重新显示当前分组，使界面反映重新加载后的内容
+func (d *document) showCurrentSection() {
+	sectionID := d.editor.CurrentSection()
+	if sectionID != "" {
+		d.editor.ShowSection(sectionID)
+	}
+}
+
+// refreshBadges 更新树中各分组覆盖了默认值的字段数
+func (d *document) refreshBadges() {
+	d.tree.SetOverrideCounts(config.OverrideCounts(d.schema, d.userConfig))
+}
+
+// refreshTabTitle 更新文档标签页的标题
+func (a *App) refreshTabTitle(doc *document) {
+	if title := doc.title(); doc.tab.Text != title {
+		doc.tab.Text = title
+		a.tabs.Refresh()
+	}
+}
+
+// setStatus 设置当前文档的状态栏文字
+func (a *App) setStatus(text string) {
+	a.setDocumentStatus(a.document, text)
+}
+
+// setDocumentStatus 设置doc的状态栏文字，doc不是活动标签页时在切换过去后显示
+func (a *App) setDocumentStatus(doc *document, text string) {
+	doc.status = text
+	if doc == a.document {
+		a.statusLabel.SetText(text)
+	}
+}
+
+// activateDocument 切换当前文档
+func (a *App) activateDocument(doc *document) {
+	a.document = doc
+	a.statusLabel.SetText(doc.status)
+}
+
+// documentFor 查找已打开该文件的文档
+func (a *App) documentFor(filePath string) *document {
+	filePath = absPath(filePath)
+	for _, doc := range a.documents {
+		if doc.currentFilePath != "" && absPath(doc.currentFilePath) == filePath {
+			return doc
+		}
+		if doc.currentFilePath == "" && doc.schemaFilePath != "" && absPath(doc.schemaFilePath) == filePath {
+			return doc
+		}
+	}
+	return nil
+}
+
+// prepareDocumentFor 为打开文件选择标签页：文件已打开时切换过去并返回false；
+// 当前标签页为空时直接使用，否则新建标签页
+func (a *App) prepareDocumentFor(filePath string) bool {
+	if doc := a.documentFor(filePath); doc != nil {
+		a.tabs.Select(doc.tab)
+		return false
+	}
+
+	if !a.isEmpty() {
+		doc := a.newDocument()
+		a.tabs.Append(doc.tab)
+		a.tabs.Select(doc.tab)
+	}
+	return true
+}
+
+// watchedFiles 所有标签页中需要监视的文件
+func (a *App) watchedFiles() []string {
+	var files []string
+	for _, doc := range a.documents {
+		files = append(files, doc.currentFilePath, doc.schemaFilePath)
+	}
+	return files
+}
+
+// setupTabs 初始化标签页容器
+func (a *App) setupTabs() {
+	first := a.newDocument()
+	a.document = first
+
+	a.tabs = container.NewDocTabs(first.tab)
+	a.tabs.CreateTab = func() *container.TabItem {
+		return a.newDocument().tab
+	}
+	a.tabs.OnSelected = func(tab *container.TabItem) {
+		if doc := a.documentForTab(tab); doc != nil {
+			a.activateDocument(doc)
+		}
+	}
+	a.tabs.CloseIntercept = func(tab *container.TabItem) {
+		doc := a.documentForTab(tab)
+		if doc == nil {
+			return
+		}
+		if !doc.isDirty() {
+			a.closeDocument(doc)
+			return
+		}
+		dialog.ShowConfirm(i18n.T("关闭标签页"),
+			i18n.T("%s 有%d项未保存的修改，确定要关闭吗？", doc.title(), len(doc.localEdits)),
+			func(confirmed bool) {
+				if confirmed {
+					a.closeDocument(doc)
+				}
+			}, a.window)
+	}
+}
+
+// documentForTab 查找标签页对应的文档
+func (a *App) documentForTab(tab *container.TabItem) *document {
+	for _, doc := range a.documents {
+		if doc.tab == tab {
+			return doc
+		}
+	}
+	return nil
+}
+
+// closeDocument 关闭文档；关闭最后一个标签页时保留一个空白标签页
+func (a *App) closeDocument(doc *document) {
+	for i, d := range a.documents {
+		if d == doc {
+			a.documents = append(a.documents[:i], a.documents[i+1:]...)
+			break
+		}
+	}
+	a.tabs.Remove(doc.tab)
+
+	if len(a.documents) == 0 {
+		blank := a.newDocument()
+		a.tabs.Append(blank.tab)
+	}
+	if selected := a.documentForTab(a.tabs.Selected()); selected != nil {
+		a.activateDocument(selected)
+	} else {
+		a.tabs.SelectIndex(0)
+		a.activateDocument(a.documents[0])
+	}
+
+	if a.watcher != nil {
+		a.watcher.Watch(a.watchedFiles()...)
+	}
+}
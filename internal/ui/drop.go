@@ -0,0 +1,241 @@
+package ui
+
+import (
+	"log"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"configcraft/internal/config"
+	"configcraft/internal/i18n"
+	"configcraft/internal/models"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+)
+
+// fileKind 打开或拖入的文件类型
+type fileKind int
+
+const (
+	fileKindUnknown fileKind = iota
+	fileKindSchema           // schema定义文件
+	fileKindConfig           // 用户配置YAML
+	fileKindConf             // 生成的conf文件
+	fileKindProject          // 项目文件
+)
+
+// detectFileKind 识别文件类型：.conf为生成的配置；*.project.yaml为项目文件；
+// 能作为schema加载的YAML为schema，其余YAML为用户配置
+func detectFileKind(filePath string) fileKind {
+	if config.IsProjectFile(filePath) {
+		return fileKindProject
+	}
+
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".conf":
+		return fileKindConf
+	case ".yaml", ".yml":
+		if err := config.NewParser().LoadSchema(filePath); err == nil {
+			return fileKindSchema
+		}
+		return fileKindConfig
+	}
+	return fileKindUnknown
+}
+
+// onFilesDropped 处理拖入窗口的文件
+// 同时拖入schema和配置文件时将两者绑定；拖入conf文件时把其中的值导入当前配置；拖入项目文件时打开整个项目
+func (a *App) onFilesDropped(_ fyne.Position, uris []fyne.URI) {
+	var schemaPath, configPath, confPath, projectPath string
+	var ignored []string
+
+	for _, uri := range uris {
+		if uri.Scheme() != "file" {
+			ignored = append(ignored, uri.String())
+			continue
+		}
+
+		filePath := uri.Path()
+		var target *string
+		switch detectFileKind(filePath) {
+		case fileKindSchema:
+			target = &schemaPath
+		case fileKindConfig:
+			target = &configPath
+		case fileKindConf:
+			target = &confPath
+		case fileKindProject:
+			target = &projectPath
+		}
+		if target == nil || *target != "" {
+			// 不支持的文件，或同类文件已有一个
+			ignored = append(ignored, filepath.Base(filePath))
+			continue
+		}
+		*target = filePath
+	}
+
+	log.Printf("Files dropped: project=%q schema=%q config=%q conf=%q ignored=%v", projectPath, schemaPath, configPath, confPath, ignored)
+
+	var summary []string
+	var err error
+
+	if projectPath != "" {
+		if err = a.loadProject(projectPath); err != nil {
+			dialog.ShowError(err, a.window)
+			return
+		}
+		summary = append(summary, i18n.T("已打开项目 %s", filepath.Base(projectPath)))
+	}
+
+	// 打开schema/配置时使用新标签页（当前标签页为空时直接使用）；conf只导入当前标签页
+	primary := configPath
+	if primary == "" {
+		primary = schemaPath
+	}
+	if primary != "" && !a.prepareDocumentFor(primary) {
+		summary = append(summary, i18n.T("%s 已打开，已切换到对应标签页", filepath.Base(primary)))
+		schemaPath, configPath = "", ""
+	}
+
+	switch {
+	case schemaPath != "" && configPath != "":
+		if err = a.loadBoundConfig(configPath, schemaPath); err == nil {
+			summary = append(summary, i18n.T("已绑定配置 %s 与 Schema %s", filepath.Base(configPath), filepath.Base(schemaPath)))
+		}
+	case schemaPath != "":
+		if err = a.loadSchemaFile(schemaPath); err == nil {
+			summary = append(summary, i18n.T("已加载Schema %s", filepath.Base(schemaPath)))
+		}
+	case configPath != "":
+		if err = a.loadConfigFile(configPath); err == nil {
+			summary = append(summary, i18n.T("已打开配置 %s", filepath.Base(configPath)))
+		}
+	}
+	if err != nil {
+		dialog.ShowError(i18n.Errorf("无法加载拖入的文件: %v", err), a.window)
+		return
+	}
+
+	if confPath != "" {
+		changed, unmatched, err := a.importConfFile(confPath)
+		if err != nil {
+			dialog.ShowError(i18n.Errorf("无法导入conf文件: %v", err), a.window)
+			return
+		}
+		summary = append(summary, confImportSummary(confPath, changed, unmatched))
+	}
+
+	if len(ignored) > 0 {
+		summary = append(summary, i18n.T("已忽略: %s", strings.Join(ignored, ", ")))
+	}
+	if len(summary) > 0 {
+		dialog.ShowInformation(i18n.T("拖放打开"), strings.Join(summary, "\n\n"), a.window)
+	}
+}
+
+// loadBoundConfig 使用指定schema加载配置文件，而不是根据配置内容动态生成schema
+func (a *App) loadBoundConfig(configPath, schemaPath string) error {
+	if err := a.parser.LoadSchema(schemaPath); err != nil {
+		return err
+	}
+	userConfig, err := a.parser.LoadUserConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	a.schema = a.parser.GetSchema()
+	a.userConfig = userConfig
+	a.currentFilePath = configPath
+	a.schemaFilePath = schemaPath
+	filled := config.MaterializeDefaults(a.schema, a.userConfig)
+	a.editor.SetSchema(a.schema)
+	a.editor.SetConfig(a.userConfig)
+	a.markClean(a.document)
+	a.markFilledDefaults(a.document, filled)
+	a.addRecentFile(schemaPath, true)
+	a.addRecentFile(configPath, false)
+
+	a.updateStatusBar(configPath)
+	if len(filled) > 0 {
+		a.setStatus(i18n.T("%s  |  已补全%d项默认值（尚未保存）", a.status, len(filled)))
+	}
+	a.refreshTree()
+	a.showFirstSection()
+
+	return nil
+}
+
+// importConfFile 将conf文件中的值导入当前配置，返回改变的配置项数和无法匹配schema的conf键
+// 没有打开任何配置时以导入的值新建配置（保存时需要选择文件位置）
+func (a *App) importConfFile(confPath string) (int, []string, error) {
+	var knownPaths []string
+	if a.userConfig != nil {
+		for fieldPath := range a.userConfig.Values {
+			knownPaths = append(knownPaths, fieldPath)
+		}
+	}
+
+	imported, unmatched, err := config.ImportConfFile(confPath, a.schema, knownPaths)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if a.userConfig == nil {
+		a.userConfig = &models.UserConfig{Values: make(map[string]interface{})}
+		a.currentFilePath = ""
+		a.markClean(a.document)
+	}
+
+	changed := 0
+	for fieldPath, value := range imported.Values {
+		if current, exists := a.userConfig.Values[fieldPath]; exists && reflect.DeepEqual(current, value) {
+			continue
+		}
+		a.userConfig.Values[fieldPath] = value
+		a.localEdits[fieldPath] = true
+		changed++
+	}
+	a.refreshTabTitle(a.document)
+
+	// 动态schema需要包含新导入的配置项
+	if a.schemaFilePath == "" {
+		a.schema = a.generateSchemaFromConfig(a.userConfig)
+		a.editor.SetSchema(a.schema)
+		a.refreshTree()
+	}
+	a.editor.SetConfig(a.userConfig)
+	a.refreshBadges()
+	if a.editor.CurrentSection() != "" {
+		a.showCurrentSection()
+	} else {
+		a.showFirstSection()
+	}
+
+	a.setStatus(i18n.T("已从 %s 导入%d项（尚未保存）", filepath.Base(confPath), changed))
+	return changed, unmatched, nil
+}
+
+// importConfFileWithMessage 导入conf文件并提示结果
+func (a *App) importConfFileWithMessage(confPath string) {
+	changed, unmatched, err := a.importConfFile(confPath)
+	if err != nil {
+		dialog.ShowError(i18n.Errorf("无法导入conf文件: %v", err), a.window)
+		return
+	}
+	dialog.ShowInformation(i18n.T("导入conf"), confImportSummary(confPath, changed, unmatched), a.window)
+}
+
+// confImportSummary conf导入结果的说明文字
+func confImportSummary(confPath string, changed int, unmatched []string) string {
+	summary := i18n.T("已从 %s 导入%d项配置", filepath.Base(confPath), changed)
+	if len(unmatched) > 0 {
+		shown := unmatched
+		if len(shown) > 10 {
+			shown = append(shown[:10:10], "...")
+		}
+		summary += i18n.T("\n%d个键无法对应到schema字段，已按一级配置项导入: %s", len(unmatched), strings.Join(shown, ", "))
+	}
+	return summary
+}
@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"log"
+	"reflect"
+
+	"configcraft/internal/config"
+	"configcraft/internal/i18n"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// showHistoryDialog 列出当前文件的变更记录（最近的在前），可以撤销其中的一项
+func (a *App) showHistoryDialog() {
+	if a.currentFilePath == "" {
+		dialog.ShowInformation(i18n.T("变更记录"), i18n.T("请先打开或保存一个配置文件"), a.window)
+		return
+	}
+
+	entries, err := config.ReadHistory(a.currentFilePath)
+	if err != nil {
+		dialog.ShowError(i18n.Errorf("读取变更记录失败: %v", err), a.window)
+		return
+	}
+	if len(entries) == 0 {
+		dialog.ShowInformation(i18n.T("变更记录"), i18n.T("%s 暂无变更记录\n\n每次保存时会把修改过的配置项记录到 %s",
+			a.title(), config.HistoryPathFor(a.currentFilePath)), a.window)
+		return
+	}
+
+	var historyDialog dialog.Dialog
+	list := widget.NewList(
+		func() int { return len(entries) },
+		func() fyne.CanvasObject {
+			title := widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+			detail := widget.NewLabel("\n") // 两行：值的变化，时间、用户和版本
+			revert := widget.NewButton(i18n.T("撤销"), nil)
+			revert.Importance = widget.LowImportance
+			return container.NewBorder(nil, nil, nil, container.NewCenter(revert), container.NewVBox(title, detail))
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			entry := entries[id]
+			row := obj.(*fyne.Container)
+			texts := row.Objects[0].(*fyne.Container)
+			texts.Objects[0].(*widget.Label).SetText(a.fieldTitle(entry.Path))
+			texts.Objects[1].(*widget.Label).SetText(i18n.T("%s → %s\n%s · %s · ConfigCraft %s",
+				historyValue(entry.Old), historyValue(entry.New),
+				entry.Time.Local().Format("2006-01-02 15:04:05"), entry.User, entry.Version))
+			row.Objects[1].(*fyne.Container).Objects[0].(*widget.Button).OnTapped = func() {
+				a.confirmRevertChange(entry, historyDialog)
+			}
+		},
+	)
+
+	content := container.NewBorder(
+		widget.NewLabel(i18n.T("撤销一项修改会把该配置项恢复为修改前的值（尚未保存）：")),
+		nil, nil, nil,
+		list,
+	)
+	historyDialog = dialog.NewCustom(i18n.T("变更记录：%s", a.title()), i18n.T(i18n.Ctx("按钮", "关闭")), content, a.window)
+	historyDialog.Resize(fyne.NewSize(620, 480))
+	historyDialog.Show()
+}
+
+// fieldTitle 配置项在schema中的名称和路径，schema中没有该字段时只显示路径
+func (a *App) fieldTitle(fieldPath string) string {
+	if field, found := config.LookupField(a.schema, fieldPath); found {
+		if label := i18n.Local(field.Label); label != "" {
+			return label + "  (" + fieldPath + ")"
+		}
+	}
+	return fieldPath
+}
+
+// historyValue 变更记录中值的显示文字，新增或删除一侧显示为"（无）"
+func historyValue(value interface{}) string {
+	if value == nil {
+		return i18n.T("（无）")
+	}
+	return config.FormatValue(value)
+}
+
+// confirmRevertChange 撤销一项修改；该配置项之后又被修改过时提示将覆盖当前值
+func (a *App) confirmRevertChange(entry config.ChangeEntry, historyDialog dialog.Dialog) {
+	current := a.userConfig.Values[entry.Path]
+	message := i18n.T("把 %s 从 %s 恢复为 %s？", a.fieldTitle(entry.Path), historyValue(entry.New), historyValue(entry.Old))
+	if !reflect.DeepEqual(current, entry.New) {
+		message = i18n.T("%s 在这次修改之后又被修改过，当前值 %s 将被恢复为 %s，确定吗？",
+			a.fieldTitle(entry.Path), historyValue(current), historyValue(entry.Old))
+	}
+
+	dialog.ShowConfirm(i18n.T("撤销修改"), message, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		config.RevertChange(a.userConfig, entry)
+		a.localEdits[entry.Path] = true
+		log.Printf("Reverted %s to its value before %s", entry.Path, entry.Time.Format("2006-01-02 15:04:05"))
+
+		// 动态schema需要包含恢复的配置项
+		if a.schemaFilePath == "" {
+			a.schema = a.generateSchemaFromConfig(a.userConfig)
+			a.editor.SetSchema(a.schema)
+			a.refreshTree()
+		}
+		a.editor.SetConfig(a.userConfig)
+		a.refreshTabTitle(a.document)
+		a.refreshBadges()
+		a.showCurrentSection()
+		historyDialog.Hide()
+		a.setStatus(i18n.T("已撤销 %s 的修改（尚未保存）", a.fieldTitle(entry.Path)))
+	}, a.window)
+}
@@ -0,0 +1,236 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"configcraft/internal/config"
+	"configcraft/internal/i18n"
+	"configcraft/internal/models"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// maxPaletteResults 命令面板最多显示的结果数
+const maxPaletteResults = 50
+
+// paletteItem 命令面板中可跳转的一项：分组、子分组或字段
+type paletteItem struct {
+	title     string // 显示名称，上级分组名称在前，如"按键 › 单击 › 动作"
+	path      string // 配置路径
+	nodeID    string // 所在的树节点
+	fieldPath string // 字段路径，分组和子分组为空
+	search    string // 参与匹配的小写文字
+}
+
+// paletteItems 当前schema中所有可跳转的项，按分组顺序
+func paletteItems(schema *models.Schema) []paletteItem {
+	var items []paletteItem
+	add := func(item paletteItem) {
+		item.search = strings.ToLower(item.title + " " + item.path)
+		items = append(items, item)
+	}
+
+	sectionKeys := make([]string, 0, len(schema.Sections))
+	for key := range schema.Sections {
+		sectionKeys = append(sectionKeys, key)
+	}
+	sort.Strings(sectionKeys)
+
+	for _, sectionKey := range sectionKeys {
+		section := schema.Sections[sectionKey]
+		sectionName := i18n.Local(section.Name)
+		add(paletteItem{title: sectionName, path: sectionKey, nodeID: sectionKey})
+
+		config.ForEachField(&models.Schema{Sections: map[string]models.ConfigSection{sectionKey: section}}, func(fieldPath string, field models.ConfigField) {
+			parts := strings.Split(fieldPath, ".")
+			if len(parts) == 2 {
+				add(paletteItem{title: sectionName + " › " + i18n.Local(field.Label), path: fieldPath, nodeID: sectionKey, fieldPath: fieldPath})
+				return
+			}
+			groupID := sectionKey + "." + parts[1]
+			groupName := sectionName + " › " + i18n.Local(section.Groups[parts[1]].Name)
+			if items[len(items)-1].nodeID != groupID {
+				add(paletteItem{title: groupName, path: groupID, nodeID: groupID})
+			}
+			add(paletteItem{title: groupName + " › " + i18n.Local(field.Label), path: fieldPath, nodeID: groupID, fieldPath: fieldPath})
+		})
+	}
+	return items
+}
+
+// fuzzyScore 模糊匹配：query中的字符按顺序出现在text中即匹配
+// 连续匹配和在词首匹配的得分更高，不匹配时返回false
+func fuzzyScore(query, text string) (int, bool) {
+	queryRunes := []rune(query)
+	if len(queryRunes) == 0 {
+		return 0, true
+	}
+
+	score := 0
+	matched := 0
+	previous := -2
+	var before rune = ' '
+	for i, r := range []rune(text) {
+		if matched < len(queryRunes) && r == queryRunes[matched] {
+			score++
+			if i == previous+1 {
+				score += 5 // 连续匹配
+			}
+			if !unicode.IsLetter(before) && !unicode.IsDigit(before) {
+				score += 3 // 词首：开头或空格、点号、下划线之后
+			}
+			previous = i
+			matched++
+		}
+		before = r
+	}
+	if matched < len(queryRunes) {
+		return 0, false
+	}
+	return score, true
+}
+
+// filterPalette 按匹配得分排序，得分相同时较短的在前，其次保持分组顺序
+func filterPalette(items []paletteItem, query string) []paletteItem {
+	query = strings.ToLower(strings.Join(strings.Fields(query), " "))
+	type scored struct {
+		item  paletteItem
+		score int
+	}
+	var matches []scored
+	for _, item := range items {
+		if score, ok := fuzzyScore(query, item.search); ok {
+			matches = append(matches, scored{item, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return len(matches[i].item.search) < len(matches[j].item.search)
+	})
+
+	if len(matches) > maxPaletteResults {
+		matches = matches[:maxPaletteResults]
+	}
+	results := make([]paletteItem, len(matches))
+	for i, match := range matches {
+		results[i] = match.item
+	}
+	return results
+}
+
+// paletteEntry 命令面板的搜索框，上下键移动选中项，Enter跳转，Esc关闭
+type paletteEntry struct {
+	widget.Entry
+	onMove   func(delta int)
+	onSubmit func()
+	onCancel func()
+}
+
+func newPaletteEntry() *paletteEntry {
+	entry := &paletteEntry{}
+	entry.ExtendBaseWidget(entry)
+	return entry
+}
+
+func (e *paletteEntry) TypedKey(event *fyne.KeyEvent) {
+	switch event.Name {
+	case fyne.KeyUp:
+		e.onMove(-1)
+	case fyne.KeyDown:
+		e.onMove(1)
+	case fyne.KeyReturn, fyne.KeyEnter:
+		e.onSubmit()
+	case fyne.KeyEscape:
+		e.onCancel()
+	default:
+		e.Entry.TypedKey(event)
+	}
+}
+
+// showCommandPalette 命令面板（Ctrl+F）：输入字段名称或路径的一部分，跳转到对应的分组和字段
+func (a *App) showCommandPalette() {
+	if a.schema == nil {
+		dialog.ShowInformation(i18n.T("跳转到字段"), i18n.T("请先打开配置文件"), a.window)
+		return
+	}
+
+	items := paletteItems(a.schema)
+	results := items
+	if len(results) > maxPaletteResults {
+		results = results[:maxPaletteResults]
+	}
+	selected := 0
+	moving := false // 用键盘移动选中项时不跳转，点击列表项时跳转
+
+	list := widget.NewList(
+		func() int { return len(results) },
+		func() fyne.CanvasObject {
+			path := widget.NewLabel("")
+			path.TextStyle = fyne.TextStyle{Monospace: true}
+			return container.NewBorder(nil, nil, nil, path, widget.NewLabel(""))
+		},
+		func(id widget.ListItemID, object fyne.CanvasObject) {
+			row := object.(*fyne.Container)
+			row.Objects[0].(*widget.Label).SetText(results[id].title)
+			row.Objects[1].(*widget.Label).SetText(results[id].path)
+		},
+	)
+
+	entry := newPaletteEntry()
+	entry.SetPlaceHolder(i18n.T("输入字段名称或路径，↑↓选择，Enter跳转"))
+
+	var palette dialog.Dialog
+	jump := func(item paletteItem) {
+		palette.Hide()
+		if !a.tree.SelectNodeByID(item.nodeID) {
+			return
+		}
+		if item.fieldPath != "" {
+			a.editor.FocusField(item.fieldPath)
+		}
+	}
+	selectResult := func(index int) {
+		if len(results) == 0 {
+			return
+		}
+		selected = (index + len(results)) % len(results)
+		moving = true
+		list.Select(selected)
+		moving = false
+	}
+
+	entry.OnChanged = func(query string) {
+		results = filterPalette(items, query)
+		list.UnselectAll()
+		list.Refresh()
+		list.ScrollToTop()
+		selectResult(0)
+	}
+	entry.onMove = func(delta int) { selectResult(selected + delta) }
+	entry.onSubmit = func() {
+		if selected < len(results) {
+			jump(results[selected])
+		}
+	}
+	entry.onCancel = func() { palette.Hide() }
+	list.OnSelected = func(id widget.ListItemID) {
+		selected = id
+		if !moving {
+			jump(results[id])
+		}
+	}
+
+	content := container.NewBorder(entry, nil, nil, nil, list)
+	palette = dialog.NewCustom(i18n.T("跳转到字段"), i18n.T(i18n.Ctx("按钮", "关闭")), content, a.window)
+	palette.Resize(fyne.NewSize(640, 460))
+	palette.Show()
+	selectResult(0)
+	a.window.Canvas().Focus(entry)
+}
@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"configcraft/internal/config"
+	"configcraft/internal/i18n"
+	"configcraft/internal/models"
+
+	"fyne.io/fyne/v2/dialog"
+)
+
+// openProject 打开项目文件：项目中的每个配置在各自的标签页中打开，并绑定项目指定的schema
+func (a *App) openProject(projectPath string) {
+	if err := a.loadProject(projectPath); err != nil {
+		dialog.ShowError(err, a.window)
+	}
+}
+
+// loadProject 加载项目中的所有配置，部分配置加载失败时在提示中列出，不影响其他配置
+func (a *App) loadProject(projectPath string) error {
+	project, err := config.LoadProject(projectPath)
+	if err != nil {
+		return i18n.Errorf("无法加载项目文件: %v", err)
+	}
+	log.Printf("Opening project: %s (%d configs)", project.FilePath, len(project.Configs))
+
+	var first *document
+	var failed []string
+	for i := range project.Configs {
+		cfg := &project.Configs[i]
+
+		// 已经打开的配置只关联到项目，不重新加载以免丢失未保存的修改
+		if doc := a.documentFor(cfg.Path); doc != nil {
+			doc.project, doc.projectConfig = project, cfg
+			a.refreshTabTitle(doc)
+			if first == nil {
+				first = doc
+			}
+			continue
+		}
+
+		a.prepareDocumentFor(cfg.Path)
+		if err := a.loadProjectConfig(project, cfg); err != nil {
+			log.Printf("Failed to open project config %s: %v", cfg.Name, err)
+			failed = append(failed, fmt.Sprintf("%s: %v", cfg.Name, err))
+			continue
+		}
+		if first == nil {
+			first = a.document
+		}
+	}
+
+	a.addRecentFile(project.FilePath, false)
+	if first != nil {
+		a.tabs.Select(first.tab)
+	}
+
+	if len(failed) > 0 {
+		return i18n.Errorf("项目 %s 中有%d个配置无法打开:\n%s",
+			projectDisplayName(project), len(failed), strings.Join(failed, "\n"))
+	}
+	a.setStatus(i18n.T("已打开项目 %s（%d个配置）", projectDisplayName(project), len(project.Configs)))
+	return nil
+}
+
+// loadProjectConfig 在当前标签页中打开项目中的一个配置
+// 配置文件尚不存在时从schema新建，保存时写到项目指定的位置
+func (a *App) loadProjectConfig(project *models.Project, cfg *models.ProjectConfig) error {
+	if fileExists(cfg.Path) {
+		if err := a.loadBoundConfig(cfg.Path, cfg.Schema); err != nil {
+			return err
+		}
+	} else {
+		if err := a.loadSchemaFile(cfg.Schema); err != nil {
+			return err
+		}
+		a.currentFilePath = cfg.Path
+	}
+
+	a.project, a.projectConfig = project, cfg
+	a.updateStatusBar(cfg.Path)
+	a.refreshTabTitle(a.document)
+	return nil
+}
+
+// projectDisplayName 项目名称，未设置时使用项目文件名
+func projectDisplayName(project *models.Project) string {
+	if project.Name != "" {
+		return project.Name
+	}
+	return filepath.Base(project.FilePath)
+}
@@ -0,0 +1,206 @@
+package ui
+
+import (
+	"log"
+	"path/filepath"
+	"reflect"
+
+	"configcraft/internal/config"
+	"configcraft/internal/i18n"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// absPath 返回绝对路径，失败时原样返回
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+// markClean 在doc加载或保存后调用：记录文件摘要、清空本地修改记录并更新监视列表
+func (a *App) markClean(doc *document) {
+	doc.localEdits = make(map[string]bool)
+	doc.fileHashes = make(map[string]string)
+	for _, path := range []string{doc.currentFilePath, doc.schemaFilePath} {
+		if path != "" {
+			doc.fileHashes[absPath(path)] = fileHash(path)
+		}
+	}
+
+	if a.watcher != nil {
+		a.watcher.Watch(a.watchedFiles()...)
+	}
+	a.refreshTabTitle(doc)
+}
+
+// watchFiles 把监视到的外部修改逐个交给界面处理，直到监视停止
+func (a *App) watchFiles() {
+	for {
+		select {
+		case change := <-a.watcher.Changes():
+			a.runOnUI(func() {
+				a.onFileChanged(change.path, change.hash)
+			})
+		case <-a.watcher.Done():
+			return
+		}
+	}
+}
+
+// runOnUI 在窗口的事件goroutine上执行fn，与点击、输入等界面回调串行，文档状态因此不需要加锁
+// Fyne 2.4没有公开这个接口，桌面驱动的窗口提供QueueEvent；其他驱动（如测试驱动）直接执行
+func (a *App) runOnUI(fn func()) {
+	if queue, ok := a.window.(interface{ QueueEvent(func()) }); ok {
+		queue.QueueEvent(fn)
+		return
+	}
+	fn()
+}
+
+// onFileChanged 被监视的文件在磁盘上发生变化，交给打开了该文件的每个标签页处理
+// 只在界面goroutine上调用
+func (a *App) onFileChanged(path, hash string) {
+	for _, doc := range a.documents {
+		if doc.fileHashes[path] != "" && doc.fileHashes[path] != hash {
+			a.handleFileChange(doc, path, hash)
+		}
+	}
+}
+
+// handleFileChange doc的文件在外部被修改（摘要不同说明不是自身保存触发的）
+func (a *App) handleFileChange(doc *document, path, hash string) {
+	switch path {
+	case absPath(doc.schemaFilePath):
+		a.reloadSchema(doc, path, hash)
+	case absPath(doc.currentFilePath):
+		if len(doc.localEdits) == 0 {
+			a.reloadConfig(doc, path, hash, false)
+		} else {
+			a.showExternalChangeDialog(doc, path, hash)
+		}
+	}
+}
+
+// reloadSchema 重新加载doc的schema，当前配置值不受影响
+func (a *App) reloadSchema(doc *document, path, hash string) {
+	if err := doc.parser.LoadSchema(path); err != nil {
+		// 可能是外部编辑器保存了一半，保留当前schema等待下一次修改
+		log.Printf("Schema changed on disk but failed to reload: %v", err)
+		a.setDocumentStatus(doc, i18n.T("Schema已在外部修改但无法加载: %s", filepath.Base(path)))
+		return
+	}
+
+	doc.fileHashes[path] = hash
+	doc.schema = doc.parser.GetSchema()
+	doc.editor.SetSchema(doc.schema)
+	doc.refreshTree()
+	doc.showCurrentSection()
+
+	log.Printf("Reloaded schema: %s", path)
+	a.setDocumentStatus(doc, i18n.T("Schema已自动重新加载: %s", filepath.Base(path)))
+}
+
+// reloadConfig 从磁盘重新加载doc的配置文件
+// merge为true时，本地修改过的字段会覆盖磁盘上的值和备注，其余字段采用磁盘版本
+func (a *App) reloadConfig(doc *document, path, hash string, merge bool) {
+	diskConfig, err := doc.parser.LoadUserConfig(path)
+	if err != nil {
+		log.Printf("Config changed on disk but failed to reload: %v", err)
+		a.setDocumentStatus(doc, i18n.T("配置文件已在外部修改但无法加载: %s", filepath.Base(path)))
+		return
+	}
+
+	conflicts := 0
+	if merge {
+		for fieldPath := range doc.localEdits {
+			localValue, localExists := doc.userConfig.Values[fieldPath]
+			diskValue, diskExists := diskConfig.Values[fieldPath]
+			if diskExists && !reflect.DeepEqual(diskValue, localValue) {
+				conflicts++
+			}
+			if localExists {
+				diskConfig.Values[fieldPath] = localValue
+			} else {
+				delete(diskConfig.Values, fieldPath)
+			}
+			config.SetFieldNote(diskConfig, fieldPath, doc.userConfig.Notes[fieldPath])
+		}
+	}
+
+	doc.fileHashes[path] = hash
+	doc.userConfig = diskConfig
+	var filled []string
+	if doc.schemaFilePath == "" {
+		// 未绑定schema时，schema由配置内容动态生成
+		doc.schema = a.generateSchemaFromConfig(diskConfig)
+	} else {
+		filled = config.MaterializeDefaults(doc.schema, diskConfig)
+	}
+	doc.editor.SetSchema(doc.schema)
+	doc.editor.SetConfig(doc.userConfig)
+	doc.refreshTree()
+	doc.showCurrentSection()
+
+	if !merge {
+		doc.localEdits = make(map[string]bool)
+	}
+	a.markFilledDefaults(doc, filled)
+
+	if merge {
+		log.Printf("Merged external changes of %s (%d local edits, %d conflicts)", path, len(doc.localEdits), conflicts)
+		a.setDocumentStatus(doc, i18n.T("已合并外部修改: %s（本地修改%d项，其中%d项覆盖了外部修改，尚未保存）",
+			filepath.Base(path), len(doc.localEdits), conflicts))
+		return
+	}
+
+	log.Printf("Reloaded config: %s", path)
+	a.setDocumentStatus(doc, i18n.T("配置文件已自动重新加载: %s", filepath.Base(path)))
+}
+
+// markFilledDefaults 把加载时补全的默认值记为doc未保存的修改，保存时写入文件
+func (a *App) markFilledDefaults(doc *document, filled []string) {
+	for _, fieldPath := range filled {
+		doc.localEdits[fieldPath] = true
+	}
+	a.refreshTabTitle(doc)
+}
+
+// showExternalChangeDialog doc有未保存的本地修改时，让用户选择如何处理外部修改
+// 对话框回调时活动标签页可能已经切换，各按钮始终作用于发生修改的doc
+func (a *App) showExternalChangeDialog(doc *document, path, hash string) {
+	message := widget.NewLabel(i18n.T(
+		"%s 已在外部被修改（例如编辑器保存或git pull）。\n\n你有%d项未保存的修改，请选择处理方式：\n"+
+			"• 合并：采用磁盘上的新版本，再应用你修改过的字段\n"+
+			"• 使用磁盘版本：放弃你的修改\n"+
+			"• 保留我的版本：忽略外部修改，下次保存时覆盖",
+		filepath.Base(path), len(doc.localEdits)))
+	message.Wrapping = fyne.TextWrapWord
+
+	changeDialog := dialog.NewCustomWithoutButtons(i18n.T("文件已在外部修改"), message, a.window)
+
+	mergeBtn := widget.NewButton(i18n.T("合并"), func() {
+		changeDialog.Hide()
+		a.reloadConfig(doc, path, hash, true)
+	})
+	mergeBtn.Importance = widget.HighImportance
+
+	reloadBtn := widget.NewButton(i18n.T("使用磁盘版本"), func() {
+		changeDialog.Hide()
+		a.reloadConfig(doc, path, hash, false)
+	})
+
+	keepBtn := widget.NewButton(i18n.T("保留我的版本"), func() {
+		changeDialog.Hide()
+		// 记住这个版本，避免同一次外部修改反复提示
+		doc.fileHashes[path] = hash
+		a.setDocumentStatus(doc, i18n.T("已忽略外部修改: %s（保存时将覆盖）", filepath.Base(path)))
+	})
+
+	changeDialog.SetButtons([]fyne.CanvasObject{keepBtn, reloadBtn, mergeBtn})
+	changeDialog.Resize(fyne.NewSize(480, 280))
+	changeDialog.Show()
+}
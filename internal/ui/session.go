@@ -0,0 +1,169 @@
+package ui
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"configcraft/internal/ui/components"
+
+	"fyne.io/fyne/v2"
+)
+
+// 偏好设置键
+const (
+	prefRecentFiles    = "recent_files"    // 最近文件，每项为"schema:路径"或"config:路径"
+	prefRestoreSession = "restore_session" // 启动时是否恢复上次会话
+	prefSessionConfig  = "session_config_file"
+	prefSessionSchema  = "session_schema_file"
+	prefSessionProject = "session_project_file"
+	prefSessionNode    = "session_selected_node"
+	prefSessionWidth   = "session_window_width"
+	prefSessionHeight  = "session_window_height"
+	prefSessionSplit   = "session_split_offset"
+	prefLanguage       = "language"  // 界面语言，未设置时根据系统环境判断
+	prefFontPath       = "font_path" // 用户指定的中文字体文件，环境变量CONFIGCRAFT_FONT优先
+	prefThemeVariant   = "theme_variant"
+	prefFontScale      = "font_scale"
+	prefDensity        = "density"
+)
+
+// maxRecentFiles 最近文件列表的最大长度
+const maxRecentFiles = 10
+
+const (
+	recentSchemaPrefix = "schema:"
+	recentConfigPrefix = "config:"
+)
+
+// recentFiles 从偏好设置读取最近文件列表，最近的在前
+func (a *App) recentFiles() []components.RecentFile {
+	var files []components.RecentFile
+	for _, entry := range a.fyneApp.Preferences().StringList(prefRecentFiles) {
+		switch {
+		case strings.HasPrefix(entry, recentSchemaPrefix):
+			files = append(files, components.RecentFile{Path: strings.TrimPrefix(entry, recentSchemaPrefix), IsSchema: true})
+		case strings.HasPrefix(entry, recentConfigPrefix):
+			files = append(files, components.RecentFile{Path: strings.TrimPrefix(entry, recentConfigPrefix)})
+		}
+	}
+	return files
+}
+
+// addRecentFile 将文件移到最近文件列表的最前面
+func (a *App) addRecentFile(filePath string, isSchema bool) {
+	filePath = absPath(filePath)
+
+	entries := []string{recentConfigPrefix + filePath}
+	if isSchema {
+		entries[0] = recentSchemaPrefix + filePath
+	}
+	for _, recent := range a.recentFiles() {
+		if recent.Path == filePath {
+			continue
+		}
+		if len(entries) >= maxRecentFiles {
+			break
+		}
+		if recent.IsSchema {
+			entries = append(entries, recentSchemaPrefix+recent.Path)
+		} else {
+			entries = append(entries, recentConfigPrefix+recent.Path)
+		}
+	}
+
+	a.fyneApp.Preferences().SetStringList(prefRecentFiles, entries)
+	a.refreshRecentFiles()
+}
+
+// clearRecentFiles 清空最近文件列表
+func (a *App) clearRecentFiles() {
+	a.fyneApp.Preferences().SetStringList(prefRecentFiles, []string{})
+	a.refreshRecentFiles()
+}
+
+// refreshRecentFiles 把最近文件列表和会话选项同步到工具栏
+func (a *App) refreshRecentFiles() {
+	a.toolbar.SetRecentFiles(a.recentFiles())
+	a.toolbar.SetRestoreSession(a.fyneApp.Preferences().Bool(prefRestoreSession))
+}
+
+// saveSession 窗口关闭时记录当前会话
+func (a *App) saveSession() {
+	prefs := a.fyneApp.Preferences()
+
+	configPath, schemaPath := "", ""
+	if a.currentFilePath != "" {
+		configPath = absPath(a.currentFilePath)
+	}
+	if a.schemaFilePath != "" {
+		schemaPath = absPath(a.schemaFilePath)
+	}
+	projectPath := ""
+	if a.project != nil {
+		projectPath = a.project.FilePath
+	}
+	prefs.SetString(prefSessionConfig, configPath)
+	prefs.SetString(prefSessionSchema, schemaPath)
+	prefs.SetString(prefSessionProject, projectPath)
+	prefs.SetString(prefSessionNode, a.tree.SelectedNodeID())
+
+	size := a.window.Canvas().Size()
+	prefs.SetFloat(prefSessionWidth, float64(size.Width))
+	prefs.SetFloat(prefSessionHeight, float64(size.Height))
+	prefs.SetFloat(prefSessionSplit, a.mainSplit.Offset)
+}
+
+// restoreSession 启用了会话恢复时，重新打开上次的文件并还原界面状态
+func (a *App) restoreSession() {
+	prefs := a.fyneApp.Preferences()
+	if !prefs.Bool(prefRestoreSession) {
+		return
+	}
+
+	if width, height := prefs.Float(prefSessionWidth), prefs.Float(prefSessionHeight); width > 0 && height > 0 {
+		a.window.Resize(fyne.NewSize(float32(width), float32(height)))
+	}
+	if offset := prefs.Float(prefSessionSplit); offset > 0 && offset < 1 {
+		a.mainSplit.SetOffset(offset)
+	}
+
+	projectPath := prefs.String(prefSessionProject)
+	hasProject := projectPath != "" && fileExists(projectPath)
+	schemaPath := prefs.String(prefSessionSchema)
+	hasSchema := schemaPath != "" && fileExists(schemaPath)
+	configPath := prefs.String(prefSessionConfig)
+	hasConfig := configPath != "" && fileExists(configPath)
+
+	var err error
+	switch {
+	case hasProject:
+		// 重新打开整个项目，再切换到上次所在的配置
+		err = a.loadProject(projectPath)
+		if doc := a.documentFor(configPath); doc != nil {
+			a.tabs.Select(doc.tab)
+		}
+	case hasSchema && hasConfig:
+		err = a.loadBoundConfig(configPath, schemaPath)
+	case hasSchema:
+		err = a.loadSchemaFile(schemaPath)
+	case hasConfig:
+		err = a.loadConfigFile(configPath)
+	}
+	if err != nil {
+		log.Printf("Failed to restore session files: %v", err)
+	}
+
+	if nodeID := prefs.String(prefSessionNode); nodeID != "" {
+		a.tree.SelectNodeByID(nodeID)
+	}
+
+	log.Printf("Session restored")
+}
+
+// fileExists 判断文件是否存在
+func fileExists(path string) bool {
+	info, err := os.Stat(filepath.Clean(path))
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,198 @@
+package ui
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strings"
+
+	"configcraft/internal/fonts"
+	"configcraft/internal/i18n"
+	"configcraft/internal/ui/components"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// settingChoice 设置中的一个选项，label为中文原文，显示时翻译
+type settingChoice struct {
+	value string
+	label string
+}
+
+var themeChoices = []settingChoice{
+	{themeSystem, "跟随系统"},
+	{themeLight, "浅色"},
+	{themeDark, "深色"},
+	{themeHighContrast, "高对比度"},
+}
+
+var densityChoices = []settingChoice{
+	{densityComfortable, "舒适"},
+	{densityCompact, "紧凑"},
+}
+
+// choiceLabels 选项的显示文字
+func choiceLabels(choices []settingChoice) []string {
+	labels := make([]string, len(choices))
+	for i, choice := range choices {
+		labels[i] = i18n.T(choice.label)
+	}
+	return labels
+}
+
+// choiceLabel 值对应的显示文字
+func choiceLabel(choices []settingChoice, value string) string {
+	for _, choice := range choices {
+		if choice.value == value {
+			return i18n.T(choice.label)
+		}
+	}
+	return i18n.T(choices[0].label)
+}
+
+// choiceValue 显示文字对应的值
+func choiceValue(choices []settingChoice, label string) string {
+	for _, choice := range choices {
+		if i18n.T(choice.label) == label {
+			return choice.value
+		}
+	}
+	return choices[0].value
+}
+
+// validChoice 检查偏好设置中保存的值，未知的值按第一个选项处理
+func validChoice(choices []settingChoice, value string) string {
+	for _, choice := range choices {
+		if choice.value == value {
+			return value
+		}
+	}
+	return choices[0].value
+}
+
+// resolveFonts 查找界面字体：打包的字体、用户指定的字体（环境变量优先于偏好设置）、系统字体
+func resolveFonts(prefs fyne.Preferences) *fonts.Set {
+	fontSet := fonts.Resolve(fonts.OptionsFromEnv(fonts.Options{
+		Path: prefs.String(prefFontPath),
+	}))
+	if !fontSet.Found() {
+		log.Printf("Warning: %s", noFontMessage)
+	}
+	return fontSet
+}
+
+// showNoFontDialog 提示没有找到可以显示中文的字体
+func (a *App) showNoFontDialog() {
+	dialog.ShowInformation(i18n.T("缺少中文字体"), noFontMessage, a.window)
+}
+
+// loadAppearance 从偏好设置读取外观设置
+func loadAppearance(prefs fyne.Preferences) appearance {
+	settings := appearance{
+		Variant:   validChoice(themeChoices, prefs.StringWithFallback(prefThemeVariant, defaultAppearance.Variant)),
+		FontScale: prefs.FloatWithFallback(prefFontScale, defaultAppearance.FontScale),
+		Density:   validChoice(densityChoices, prefs.StringWithFallback(prefDensity, defaultAppearance.Density)),
+	}
+	settings.FontScale = math.Max(minFontScale, math.Min(maxFontScale, settings.FontScale))
+	return settings
+}
+
+// saveAppearance 保存外观设置
+func (a *App) saveAppearance(settings appearance) {
+	prefs := a.fyneApp.Preferences()
+	prefs.SetString(prefThemeVariant, settings.Variant)
+	prefs.SetFloat(prefFontScale, settings.FontScale)
+	prefs.SetString(prefDensity, settings.Density)
+}
+
+// applyAppearance 用外观设置和当前字体重新设置主题，所有窗口立即刷新
+func (a *App) applyAppearance(settings appearance) {
+	a.appearance = settings
+	a.fyneApp.Settings().SetTheme(newAppTheme(a.fonts, settings))
+}
+
+// showSettingsDialog 外观设置：主题、字体大小、界面密度和字体文件
+// 修改主题、字体大小和密度时立即预览，取消时恢复原来的设置
+func (a *App) showSettingsDialog() {
+	original := a.appearance
+	current := original
+
+	themeSelect := widget.NewSelect(choiceLabels(themeChoices), func(label string) {
+		current.Variant = choiceValue(themeChoices, label)
+		a.applyAppearance(current)
+	})
+	themeSelect.Selected = choiceLabel(themeChoices, current.Variant)
+
+	scaleLabel := widget.NewLabel(fmt.Sprintf("%d%%", int(math.Round(current.FontScale*100))))
+	scaleSlider := widget.NewSlider(minFontScale*100, maxFontScale*100)
+	scaleSlider.Step = 5
+	scaleSlider.Value = current.FontScale * 100
+	scaleSlider.OnChanged = func(value float64) {
+		scaleLabel.SetText(fmt.Sprintf("%d%%", int(math.Round(value))))
+	}
+	scaleSlider.OnChangeEnded = func(value float64) {
+		current.FontScale = value / 100
+		a.applyAppearance(current)
+	}
+
+	densityRadio := widget.NewRadioGroup(choiceLabels(densityChoices), nil)
+	densityRadio.Horizontal = true
+	densityRadio.Selected = choiceLabel(densityChoices, current.Density)
+	densityRadio.OnChanged = func(label string) {
+		if label == "" {
+			densityRadio.SetSelected(choiceLabel(densityChoices, current.Density)) // 不允许取消选择
+			return
+		}
+		current.Density = choiceValue(densityChoices, label)
+		a.applyAppearance(current)
+	}
+
+	prefs := a.fyneApp.Preferences()
+	fontEntry := widget.NewEntry()
+	fontEntry.SetText(prefs.String(prefFontPath))
+	fontEntry.SetPlaceHolder(i18n.T("留空则自动查找中文字体"))
+	browseFont := widget.NewButton(i18n.T("浏览"), func() {
+		if filePath, err := components.NewZenityFileDialog().ShowFontDialog(i18n.T("选择字体文件")); err == nil {
+			fontEntry.SetText(filePath)
+		}
+	})
+	fontHint := i18n.T("当前字体: %s", i18n.T("未找到中文字体"))
+	if a.fonts.Found() {
+		fontHint = i18n.T("当前字体: %s", a.fonts.Regular.Family)
+	}
+	if os.Getenv(fonts.EnvFont) != "" {
+		fontHint += "\n" + i18n.T("环境变量%s已指定字体，优先于此设置", fonts.EnvFont)
+	}
+
+	form := widget.NewForm(
+		widget.NewFormItem(i18n.T("主题"), themeSelect),
+		widget.NewFormItem(i18n.T("字体大小"), container.NewBorder(nil, nil, nil, scaleLabel, scaleSlider)),
+		widget.NewFormItem(i18n.T("界面密度"), densityRadio),
+		widget.NewFormItem(i18n.T("字体文件"), container.NewBorder(nil, nil, nil, browseFont, fontEntry)),
+	)
+	content := container.NewVBox(form, widget.NewLabel(fontHint))
+
+	settingsDialog := dialog.NewCustomConfirm(i18n.T("设置"), i18n.T("确定"), i18n.T("取消"), content, func(confirmed bool) {
+		if !confirmed {
+			a.applyAppearance(original)
+			return
+		}
+		a.saveAppearance(current)
+
+		fontPath := strings.TrimSpace(fontEntry.Text)
+		if fontPath != prefs.String(prefFontPath) {
+			prefs.SetString(prefFontPath, fontPath)
+			a.fonts = resolveFonts(prefs)
+			a.applyAppearance(current)
+			if !a.fonts.Found() {
+				a.showNoFontDialog()
+			}
+		}
+	}, a.window)
+	settingsDialog.Resize(fyne.NewSize(520, 320))
+	settingsDialog.Show()
+}
@@ -0,0 +1,21 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+)
+
+// setupShortcuts 注册窗口快捷键：Ctrl+S保存、Ctrl+O打开、Ctrl+F跳转到字段（macOS上为Cmd）
+func (a *App) setupShortcuts() {
+	shortcuts := map[fyne.KeyName]func(){
+		fyne.KeyS: a.toolbar.Save,
+		fyne.KeyO: a.toolbar.Open,
+		fyne.KeyF: a.showCommandPalette,
+	}
+	for key, action := range shortcuts {
+		action := action
+		a.window.Canvas().AddShortcut(&desktop.CustomShortcut{KeyName: key, Modifier: fyne.KeyModifierShortcutDefault}, func(fyne.Shortcut) {
+			action()
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package ui
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"configcraft/internal/i18n"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// 复制范围，显示时翻译
+const (
+	copyScopeSection = "当前分组"
+	copyScopeAll     = "全部配置项"
+)
+
+// showCopyDialog 把当前标签页的字段值复制到另一个标签页
+// 例如在左/右耳或不同SKU的配置之间同步一组按键设置
+func (a *App) showCopyDialog() {
+	if a.userConfig == nil || len(a.userConfig.Values) == 0 {
+		dialog.ShowInformation(i18n.T("复制配置值"), i18n.T("当前标签页没有可复制的配置值"), a.window)
+		return
+	}
+
+	var targets []*document
+	var targetNames []string
+	for _, doc := range a.documents {
+		if doc != a.document && doc.userConfig != nil {
+			targets = append(targets, doc)
+			targetNames = append(targetNames, doc.title())
+		}
+	}
+	if len(targets) == 0 {
+		dialog.ShowInformation(i18n.T("复制配置值"), i18n.T("请先在其他标签页中打开要复制到的配置"), a.window)
+		return
+	}
+
+	targetSelect := widget.NewSelect(targetNames, nil)
+	targetSelect.SetSelectedIndex(0)
+
+	// 字段列表的显示文字 -> 配置路径
+	pathByLabel := make(map[string]string)
+	fields := widget.NewCheckGroup(nil, nil)
+	fillFields := func(scope string) {
+		prefix := a.editor.CurrentSection() + "."
+		var labels []string
+		pathByLabel = make(map[string]string)
+		for fieldPath, value := range a.userConfig.Values {
+			if scope == i18n.T(copyScopeSection) && !strings.HasPrefix(fieldPath, prefix) {
+				continue
+			}
+			label := fmt.Sprintf("%s = %v", fieldPath, value)
+			pathByLabel[label] = fieldPath
+			labels = append(labels, label)
+		}
+		sort.Strings(labels)
+		fields.Options = labels
+		fields.SetSelected(labels)
+		fields.Refresh()
+	}
+
+	scope := widget.NewRadioGroup([]string{i18n.T(copyScopeSection), i18n.T(copyScopeAll)}, fillFields)
+	scope.Horizontal = true
+	if a.editor.CurrentSection() == "" {
+		scope.SetSelected(i18n.T(copyScopeAll))
+	} else {
+		scope.SetSelected(i18n.T(copyScopeSection))
+	}
+
+	fieldScroll := container.NewVScroll(fields)
+	fieldScroll.SetMinSize(fyne.NewSize(500, 260))
+
+	content := container.NewBorder(
+		container.NewVBox(
+			widget.NewForm(
+				widget.NewFormItem(i18n.T("复制到"), targetSelect),
+				widget.NewFormItem(i18n.T("范围"), scope),
+			),
+			widget.NewSeparator(),
+		),
+		nil, nil, nil,
+		fieldScroll,
+	)
+
+	copyDialog := dialog.NewCustomConfirm(i18n.T("复制配置值"), i18n.T("复制"), i18n.T("取消"), content, func(confirmed bool) {
+		if !confirmed || targetSelect.SelectedIndex() < 0 {
+			return
+		}
+
+		var paths []string
+		for _, label := range fields.Selected {
+			paths = append(paths, pathByLabel[label])
+		}
+		target := targets[targetSelect.SelectedIndex()]
+		copied := a.copyValues(target, paths)
+		dialog.ShowInformation(i18n.T("复制配置值"), i18n.T("已复制%d项到 %s（尚未保存）", copied, target.title()), a.window)
+	}, a.window)
+	copyDialog.Resize(fyne.NewSize(560, 460))
+	copyDialog.Show()
+}
+
+// copyValues 把当前文档的指定字段值复制到目标文档，返回实际改变的字段数
+func (a *App) copyValues(target *document, paths []string) int {
+	copied := 0
+	for _, fieldPath := range paths {
+		value, exists := a.userConfig.Values[fieldPath]
+		if !exists {
+			continue
+		}
+		if current, exists := target.userConfig.Values[fieldPath]; exists && reflect.DeepEqual(current, value) {
+			continue
+		}
+		target.userConfig.Values[fieldPath] = value
+		target.localEdits[fieldPath] = true
+		copied++
+	}
+
+	// 动态schema需要包含新复制过去的配置项
+	if target.schemaFilePath == "" {
+		target.schema = a.generateSchemaFromConfig(target.userConfig)
+		target.editor.SetSchema(target.schema)
+		target.refreshTree()
+	}
+	target.editor.SetConfig(target.userConfig)
+	target.refreshBadges()
+	target.showCurrentSection()
+	a.refreshTabTitle(target)
+
+	return copied
+}
@@ -2,65 +2,144 @@ package ui
 
 import (
 	"image/color"
-	
+
+	"configcraft/internal/fonts"
+
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/theme"
 )
 
-type chineseTheme struct {
-	fyne.Theme
+// 主题配色
+const (
+	themeSystem       = "system" // 跟随系统的浅色/深色设置
+	themeLight        = "light"
+	themeDark         = "dark"
+	themeHighContrast = "high_contrast" // 高对比度，浅色或深色跟随系统
+)
+
+// 界面密度
+const (
+	densityComfortable = "comfortable"
+	densityCompact     = "compact"
+)
+
+// 字体缩放范围
+const (
+	minFontScale = 0.8
+	maxFontScale = 1.5
+)
+
+// compactSpacing 紧凑密度下间距相对默认值的比例
+const compactSpacing = 0.5
+
+// appearance 外观设置，在设置对话框中修改并保存在偏好设置中
+type appearance struct {
+	Variant   string
+	FontScale float64
+	Density   string
+}
+
+// defaultAppearance 默认外观
+var defaultAppearance = appearance{Variant: themeSystem, FontScale: 1, Density: densityComfortable}
+
+// appTheme 应用主题：CJK字体，以及外观设置中的配色、字体大小和间距
+type appTheme struct {
+	fonts      *fonts.Set
+	appearance appearance
 }
 
-// NewChineseTheme creates a new theme with Chinese font support
-func NewChineseTheme() fyne.Theme {
-	return &chineseTheme{Theme: theme.DefaultTheme()}
+func newAppTheme(fontSet *fonts.Set, settings appearance) fyne.Theme {
+	return &appTheme{fonts: fontSet, appearance: settings}
 }
 
-func (c *chineseTheme) Font(style fyne.TextStyle) fyne.Resource {
-	// 返回默认字体，配合环境变量FYNE_FONT使用
+func (t *appTheme) Font(style fyne.TextStyle) fyne.Resource {
+	if f := t.fonts.For(style); f != nil {
+		return f.Resource
+	}
 	return theme.DefaultTheme().Font(style)
 }
 
-func (c *chineseTheme) Color(name fyne.ThemeColorName, variant fyne.ThemeVariant) color.Color {
+func (t *appTheme) Color(name fyne.ThemeColorName, variant fyne.ThemeVariant) color.Color {
+	switch t.appearance.Variant {
+	case themeLight:
+		variant = theme.VariantLight
+	case themeDark:
+		variant = theme.VariantDark
+	case themeHighContrast:
+		if c, exists := highContrastColors[variant][name]; exists {
+			return c
+		}
+	}
 	return theme.DefaultTheme().Color(name, variant)
 }
 
-func (c *chineseTheme) Icon(name fyne.ThemeIconName) fyne.Resource {
+func (t *appTheme) Icon(name fyne.ThemeIconName) fyne.Resource {
 	return theme.DefaultTheme().Icon(name)
 }
 
-func (c *chineseTheme) Size(name fyne.ThemeSizeName) float32 {
+func (t *appTheme) Size(name fyne.ThemeSizeName) float32 {
+	size := theme.DefaultTheme().Size(name)
 	switch name {
 	case theme.SizeNameText:
-		return 14 // 适合中文显示的字体大小
-	case theme.SizeNameCaptionText:
-		return 12
-	case theme.SizeNameHeadingText:
-		return 18
-	default:
-		return theme.DefaultTheme().Size(name)
+		return 14 * float32(t.appearance.FontScale) // 适合中文显示的字体大小
+	case theme.SizeNameCaptionText, theme.SizeNameHeadingText, theme.SizeNameSubHeadingText, theme.SizeNameInlineIcon:
+		return size * float32(t.appearance.FontScale)
+	case theme.SizeNamePadding, theme.SizeNameInnerPadding, theme.SizeNameLineSpacing:
+		if t.appearance.Density == densityCompact {
+			return size * compactSpacing
+		}
 	}
+	return size
 }
 
-// 简化版本：使用Fyne内置的字体回退机制
-type simpleChineseTheme struct {
-	fyne.Theme
-}
-
-func NewSimpleChineseTheme() fyne.Theme {
-	return &simpleChineseTheme{Theme: theme.DefaultTheme()}
+// highContrastColors 高对比度配色，未列出的颜色使用默认主题
+var highContrastColors = map[fyne.ThemeVariant]map[fyne.ThemeColorName]color.Color{
+	theme.VariantLight: {
+		theme.ColorNameBackground:        color.White,
+		theme.ColorNameForeground:        color.Black,
+		theme.ColorNameButton:            color.NRGBA{R: 0xd0, G: 0xd0, B: 0xd0, A: 0xff},
+		theme.ColorNameDisabledButton:    color.NRGBA{R: 0xbd, G: 0xbd, B: 0xbd, A: 0xff},
+		theme.ColorNameDisabled:          color.NRGBA{R: 0x5a, G: 0x5a, B: 0x5a, A: 0xff},
+		theme.ColorNameInputBackground:   color.White,
+		theme.ColorNameInputBorder:       color.Black,
+		theme.ColorNamePlaceHolder:       color.NRGBA{R: 0x4a, G: 0x4a, B: 0x4a, A: 0xff},
+		theme.ColorNamePrimary:           color.NRGBA{R: 0x00, G: 0x33, B: 0xcc, A: 0xff},
+		theme.ColorNameHyperlink:         color.NRGBA{R: 0x00, G: 0x33, B: 0xcc, A: 0xff},
+		theme.ColorNameFocus:             color.NRGBA{R: 0x00, G: 0x33, B: 0xcc, A: 0x80},
+		theme.ColorNameHover:             color.NRGBA{A: 0x33},
+		theme.ColorNamePressed:           color.NRGBA{A: 0x66},
+		theme.ColorNameSelection:         color.NRGBA{R: 0xff, G: 0xd6, A: 0x99},
+		theme.ColorNameSeparator:         color.Black,
+		theme.ColorNameScrollBar:         color.NRGBA{A: 0x99},
+		theme.ColorNameMenuBackground:    color.White,
+		theme.ColorNameOverlayBackground: color.White,
+		theme.ColorNameHeaderBackground:  color.NRGBA{R: 0xe0, G: 0xe0, B: 0xe0, A: 0xff},
+		theme.ColorNameError:             color.NRGBA{R: 0xb0, B: 0x20, A: 0xff},
+		theme.ColorNameSuccess:           color.NRGBA{G: 0x64, A: 0xff},
+		theme.ColorNameWarning:           color.NRGBA{R: 0x8a, G: 0x4b, A: 0xff},
+	},
+	theme.VariantDark: {
+		theme.ColorNameBackground:        color.Black,
+		theme.ColorNameForeground:        color.White,
+		theme.ColorNameButton:            color.NRGBA{R: 0x33, G: 0x33, B: 0x33, A: 0xff},
+		theme.ColorNameDisabledButton:    color.NRGBA{R: 0x40, G: 0x40, B: 0x40, A: 0xff},
+		theme.ColorNameDisabled:          color.NRGBA{R: 0xb0, G: 0xb0, B: 0xb0, A: 0xff},
+		theme.ColorNameInputBackground:   color.Black,
+		theme.ColorNameInputBorder:       color.White,
+		theme.ColorNamePlaceHolder:       color.NRGBA{R: 0xc0, G: 0xc0, B: 0xc0, A: 0xff},
+		theme.ColorNamePrimary:           color.NRGBA{R: 0xff, G: 0xd6, A: 0xff},
+		theme.ColorNameHyperlink:         color.NRGBA{R: 0x66, G: 0xb3, B: 0xff, A: 0xff},
+		theme.ColorNameFocus:             color.NRGBA{R: 0xff, G: 0xd6, A: 0x80},
+		theme.ColorNameHover:             color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x33},
+		theme.ColorNamePressed:           color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x66},
+		theme.ColorNameSelection:         color.NRGBA{R: 0xff, G: 0xd6, A: 0x66},
+		theme.ColorNameSeparator:         color.White,
+		theme.ColorNameScrollBar:         color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x99},
+		theme.ColorNameMenuBackground:    color.Black,
+		theme.ColorNameOverlayBackground: color.Black,
+		theme.ColorNameHeaderBackground:  color.NRGBA{R: 0x1a, G: 0x1a, B: 0x1a, A: 0xff},
+		theme.ColorNameError:             color.NRGBA{R: 0xff, G: 0x6e, B: 0x6e, A: 0xff},
+		theme.ColorNameSuccess:           color.NRGBA{R: 0x6e, G: 0xff, B: 0x6e, A: 0xff},
+		theme.ColorNameWarning:           color.NRGBA{R: 0xff, G: 0xc0, B: 0x4d, A: 0xff},
+	},
 }
-
-func (s *simpleChineseTheme) Font(style fyne.TextStyle) fyne.Resource {
-	// 返回默认字体，配合环境变量FYNE_FONT使用
-	return theme.DefaultTheme().Font(style)
-}
-
-func (s *simpleChineseTheme) Size(name fyne.ThemeSizeName) float32 {
-	switch name {
-	case theme.SizeNameText:
-		return 14 // 稍大的字体便于中文显示
-	default:
-		return theme.DefaultTheme().Size(name)
-	}
-}
\ No newline at end of file
@@ -0,0 +1,184 @@
+package ui
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce 合并编辑器/git在短时间内产生的多次写入事件
+const watchDebounce = 300 * time.Millisecond
+
+// fileChange 一个被监视文件的新内容摘要
+type fileChange struct {
+	path string
+	hash string
+}
+
+// fileWatcher 监视若干文件的外部修改
+// 监视的是文件所在目录而不是文件本身，这样编辑器"写临时文件再重命名"的保存方式也能被捕获
+// 后台只负责计算摘要，修改通过Changes()交给界面处理，文档状态只在界面一侧读写
+type fileWatcher struct {
+	watcher *fsnotify.Watcher
+	changes chan fileChange
+	done    chan struct{} // 监视停止后关闭
+
+	mu     sync.Mutex
+	files  map[string]bool        // 被监视的文件（绝对路径）
+	dirs   map[string]bool        // 已加入fsnotify的目录
+	timers map[string]*time.Timer // 每个文件的去抖定时器
+}
+
+func newFileWatcher() (*fileWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	fw := &fileWatcher{
+		watcher: watcher,
+		changes: make(chan fileChange, 16),
+		done:    make(chan struct{}),
+		files:   make(map[string]bool),
+		dirs:    make(map[string]bool),
+		timers:  make(map[string]*time.Timer),
+	}
+	go fw.run()
+
+	return fw, nil
+}
+
+// Watch 将监视列表替换为给定的文件，空路径会被忽略
+func (fw *fileWatcher) Watch(paths ...string) {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+
+	files := make(map[string]bool)
+	dirs := make(map[string]bool)
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		files[path] = true
+		dirs[filepath.Dir(path)] = true
+	}
+
+	for dir := range fw.dirs {
+		if !dirs[dir] {
+			fw.watcher.Remove(dir)
+		}
+	}
+	for dir := range dirs {
+		if !fw.dirs[dir] {
+			if err := fw.watcher.Add(dir); err != nil {
+				log.Printf("Failed to watch %s: %v", dir, err)
+				delete(dirs, dir)
+			}
+		}
+	}
+
+	fw.files = files
+	fw.dirs = dirs
+}
+
+// Changes 返回外部修改的通知
+func (fw *fileWatcher) Changes() <-chan fileChange {
+	return fw.changes
+}
+
+// Done 监视停止后关闭
+func (fw *fileWatcher) Done() <-chan struct{} {
+	return fw.done
+}
+
+// Close 停止监视
+func (fw *fileWatcher) Close() {
+	fw.watcher.Close()
+}
+
+func (fw *fileWatcher) run() {
+	defer fw.stop()
+	for {
+		select {
+		case event, ok := <-fw.watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Has(fsnotify.Write) || event.Has(fsnotify.Create) || event.Has(fsnotify.Rename) {
+				fw.schedule(filepath.Clean(event.Name))
+			}
+		case err, ok := <-fw.watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("File watcher error: %v", err)
+		}
+	}
+}
+
+// stop 取消尚未触发的定时器并通知监视已停止
+func (fw *fileWatcher) stop() {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+
+	for path, timer := range fw.timers {
+		timer.Stop()
+		delete(fw.timers, path)
+	}
+	fw.files = nil
+	close(fw.done)
+}
+
+// schedule 在事件平息后才计算摘要并通知，避免读到写了一半的文件
+func (fw *fileWatcher) schedule(path string) {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+
+	if !fw.files[path] {
+		return
+	}
+	if timer, exists := fw.timers[path]; exists {
+		timer.Stop()
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(watchDebounce, func() {
+		hash := fileHash(path)
+
+		fw.mu.Lock()
+		current := fw.timers[path] == timer
+		if current {
+			delete(fw.timers, path)
+		}
+		watched := fw.files[path]
+		fw.mu.Unlock()
+
+		// 已被新的事件取代、不再监视，或文件被删除/正在被替换
+		if !current || !watched || hash == "" {
+			return
+		}
+		select {
+		case fw.changes <- fileChange{path: path, hash: hash}:
+		case <-fw.done:
+		}
+	})
+	fw.timers[path] = timer
+}
+
+// fileHash 计算文件内容的摘要，用于区分真正的外部修改和自身保存触发的事件
+func fileHash(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}